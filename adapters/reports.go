@@ -10,15 +10,15 @@ import (
 )
 
 var (
-	reReportFile = regexp.MustCompile(`^([0-9]{3,4})-([0-9]{1,2})\.([0-9]{3,4})\.report\.(docx|txt)$`)
+	reReportFile = regexp.MustCompile(`^([0-9]{3,4})-([0-9]{1,2})\.([0-9]{3,4})\.report\.(docx|doc|odt|rtf|txt)$`)
 )
 
 // ReportFileNameToClanTurn returns the clan and turn number extracted
 // from the name. Returns false if any component of the name is not valid.
 // verifies that the path contains a valid report file name.
 // Because of Raven, we don't require an exact match. We accept the name if
-// it is kind of close to the YYYY-MM.CLAN.report pattern. We accept only
-// docx and txt for the extension.
+// it is kind of close to the YYYY-MM.CLAN.report pattern. We accept docx,
+// doc, odt, rtf and txt for the extension.
 // Returns false if the name is not valid.
 // Otherwise, returns the clan, year, and month extracted from the name.
 func ReportFileNameToClanTurn(path string) (clan tribal.ClanId_t, turn tribal.TurnId_t, ok bool) {
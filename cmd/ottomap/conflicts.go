@@ -0,0 +1,182 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"github.com/playbymail/tribal/norm"
+	"github.com/playbymail/tribal/parser"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/section"
+	"github.com/playbymail/tribal/tiles"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+)
+
+var (
+	argsConflicts struct {
+		paths []string // paths to the report files
+		json  bool     // write the conflicts as JSON
+	}
+
+	cmdConflicts = &cobra.Command{
+		Use:   "conflicts",
+		Short: "list the hexes where the reports disagree",
+		Long: `List the hexes where sources disagree on the terrain, settlement,
+borders or passages in the same turn.
+
+Each fact is listed with its source (visit, status, neighbor, fleet,
+alliance or override), the unit that observed it, and the line of the
+report that it came from.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(argsConflicts.paths) == 0 {
+				return fmt.Errorf("file is required")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			// the units are loaded one report at a time so that we can
+			// find the report lines for the facts.
+			var units []*ast.Unit_t
+			reports := map[reportKey_t]*reportLines_t{}
+			for _, path := range argsConflicts.paths {
+				lines, list, err := loadReportLines(path)
+				if err != nil {
+					log.Fatalf("conflicts: %v", err)
+				}
+				for _, u := range list {
+					reports[reportKey(u.Id, turnId(u))] = lines
+				}
+				units = append(units, list...)
+			}
+			list := tiles.Build(units).Conflicts()
+			if err := writeConflicts(os.Stdout, list, reports, argsConflicts.json); err != nil {
+				log.Fatalf("conflicts: %v", err)
+			}
+		},
+	}
+)
+
+// reportLines_t maps the line numbers in the facts back to the original report.
+// The facts have line numbers in the normalized report; the parser knows where
+// each of those lines came from.
+type reportLines_t struct {
+	report   *parser.Report_t
+	original [][]byte // lines (or paragraphs) of the original report
+}
+
+// source returns the line number and text of the line in the original report.
+// Returns 0 if the line is unknown.
+func (r *reportLines_t) source(line int) (int, string) {
+	if r == nil {
+		return 0, ""
+	}
+	no := r.report.Source(line)
+	if no < 1 || no > len(r.original) {
+		return 0, ""
+	}
+	return no, string(bytes.TrimSpace(r.original[no-1]))
+}
+
+// loadReportLines parses the report and returns its lines and units.
+// The units are parsed from the normalized lines, like the import command does.
+func loadReportLines(path string) (*reportLines_t, []*ast.Unit_t, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	rpt, err := parser.Report(path, parser.WithData(data))
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", path, err)
+	}
+	input, err := readReportText(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	p := section.New(section.DefaultConfig())
+	p.Log = log.New(io.Discard, "", 0)
+	var units []*ast.Unit_t
+	for _, s := range p.Parse(path, bytes.Join(rpt.Lines, []byte{'\n'})) {
+		if s.Unit != nil {
+			units = append(units, s.Unit)
+		}
+	}
+	return &reportLines_t{report: rpt, original: bytes.Split(norm.LineEndings(input), []byte{'\n'})}, units, nil
+}
+
+// reportKey_t identifies the report that a unit came from.
+// Each clan gets one report per turn.
+type reportKey_t struct {
+	clan string
+	turn ast.TurnId_t
+}
+
+func reportKey(id ast.UnitId_t, turn ast.TurnId_t) reportKey_t {
+	k := reportKey_t{turn: turn}
+	if len(id) >= 4 {
+		k.clan = string(id[1:4])
+	}
+	return k
+}
+
+func turnId(u *ast.Unit_t) ast.TurnId_t {
+	if u.Turn == nil {
+		return 0
+	}
+	return u.Turn.Id
+}
+
+// writeConflicts writes the conflicts as text or JSON.
+// Each fact is written with the text of the report line it came from.
+func writeConflicts(w io.Writer, list []*tiles.Conflict_t, reports map[reportKey_t]*reportLines_t, asJSON bool) error {
+	source := func(f *tiles.Fact_t) (int, string) {
+		return reports[reportKey(f.Unit, f.Turn)].source(f.Line)
+	}
+
+	if asJSON {
+		type fact_t struct {
+			*tiles.Fact_t
+			Line int    `json:"line,omitempty"` // line in the original report
+			Text string `json:"text,omitempty"`
+		}
+		type conflict_t struct {
+			*tiles.Conflict_t
+			Facts []fact_t `json:"facts"`
+		}
+		out := []conflict_t{}
+		for _, c := range list {
+			cc := conflict_t{Conflict_t: c}
+			for _, f := range c.Facts {
+				line, text := source(f)
+				cc.Facts = append(cc.Facts, fact_t{Fact_t: f, Line: line, Text: text})
+			}
+			out = append(out, cc)
+		}
+		data, err := json.MarshalIndent(out, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "conflicts: %d\n", len(list)); err != nil {
+		return err
+	}
+	for _, c := range list {
+		if _, err := fmt.Fprintf(w, "  %s\n", c); err != nil {
+			return err
+		}
+		for _, f := range c.Facts {
+			line, text := source(f)
+			if _, err := fmt.Fprintf(w, "    %-16s %-8s %-8s line %d: %s\n", f.Value, f.Source, f.Unit, line, text); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"bytes"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/terrain"
+	"github.com/playbymail/tribal/tiles"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLoadReportLines verifies that the line numbers in the parsed units
+// map back to the original report, even with blank lines in it.
+func TestLoadReportLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "0900-05.0987.report.txt")
+	input := "\n" +
+		"Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0608)\n" +
+		"\n" +
+		"Current Turn 900-05 (#5), Summer, FINE\n" +
+		"Tribe Movement: Move\n" +
+		"\n" +
+		"0987 Status: PRAIRIE,0987\n"
+	if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lines, units, err := loadReportLines(path)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(units) != 1 || units[0].Status == nil {
+		t.Fatalf("units: want 1 with status, got %d", len(units))
+	}
+	no, text := lines.source(units[0].Status.Line)
+	if no != 7 || text != "0987 Status: PRAIRIE,0987" {
+		t.Errorf("status: want line 7 %q, got line %d %q", "0987 Status: PRAIRIE,0987", no, text)
+	}
+}
+
+// TestWriteConflictsFleet verifies that a fleet that reports "unknown water"
+// where a unit found ocean in the same turn is listed with both sources.
+// The parser doesn't read fleet movement yet, so the units are built by hand.
+func TestWriteConflictsFleet(t *testing.T) {
+	kp0608 := ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8}
+	march := func(id ast.UnitId_t, ter terrain.Terrain_e, line int) *ast.Unit_t {
+		return &ast.Unit_t{
+			Id:         id,
+			CurrentHex: kp0608,
+			Turn:       &ast.Turn_t{Id: 5},
+			Moves: &ast.Moves_t{
+				Marches: []*ast.March_t{{Direction: direction.North, To: kp0608, Terrain: ter, Line: line}},
+			},
+		}
+	}
+	m := tiles.Build([]*ast.Unit_t{march("0987f1", terrain.UnknownWater, 2), march("0987", terrain.Ocean, 3)})
+	if got := m.Tiles[tiles.Point_t{Column: 15*30 + 6, Row: 10*21 + 8}]; got.Terrain != terrain.Ocean || got.Source != tiles.VisitSource {
+		t.Errorf("terrain: want %s/%s, got %s/%s", terrain.Ocean, tiles.VisitSource, got.Terrain, got.Source)
+	}
+	list := m.Conflicts()
+	if len(list) != 1 {
+		t.Fatalf("conflicts: want 1, got %d", len(list))
+	}
+
+	// the normalized lines 2 and 3 are lines 3 and 5 of the original report
+	reports := map[reportKey_t]*reportLines_t{
+		reportKey("0987", 5): {
+			report: &parser.Report_t{Sources: []int{1, 3, 5}},
+			original: [][]byte{
+				[]byte("Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0508)"),
+				[]byte(""),
+				[]byte("Fleet 0987f1 sailed past unknown water"),
+				[]byte(""),
+				[]byte("Tribe 0987 landed on ocean"),
+			},
+		},
+	}
+	bb := &bytes.Buffer{}
+	if err := writeConflicts(bb, list, reports, false); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"UW               fleet    0987f1   line 3: Fleet 0987f1 sailed past unknown water",
+		"O                visit    0987     line 5: Tribe 0987 landed on ocean",
+	} {
+		if !strings.Contains(bb.String(), want) {
+			t.Errorf("output: want %q, got\n%s", want, bb.String())
+		}
+	}
+}
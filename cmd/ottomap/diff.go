@@ -0,0 +1,107 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/render"
+	"github.com/playbymail/tribal/tiles"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+)
+
+var (
+	argsDiff struct {
+		paths   []string // paths to the report files
+		from    string   // turn to compare from, e.g. "901-03"
+		to      string   // turn to compare to, e.g. "901-04"
+		json    bool     // write the changes as JSON
+		png     string   // path to write the highlighted map to
+		hexSize int      // distance from the center of a hex to a corner, in pixels
+	}
+
+	cmdDiff = &cobra.Command{
+		Use:   "diff",
+		Short: "list what changed on the map between two turns",
+		Long: `List what changed on the map between the end of two turns.
+
+The changes include new tiles, refined terrain, new or vanished settlements,
+new borders and passages, resources found, and foreign units that appeared
+or vanished. Use --png to draw the map with the changed tiles outlined.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(argsDiff.paths) == 0 {
+				return fmt.Errorf("file is required")
+			} else if argsDiff.from == "" || argsDiff.to == "" {
+				return fmt.Errorf("from and to are required")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			from, err := ast.TextToTurnId([]byte(argsDiff.from))
+			if err != nil {
+				log.Fatalf("diff: from %q: %v", argsDiff.from, err)
+			}
+			to, err := ast.TextToTurnId([]byte(argsDiff.to))
+			if err != nil {
+				log.Fatalf("diff: to %q: %v", argsDiff.to, err)
+			}
+			units, err := loadUnits(argsDiff.paths)
+			if err != nil {
+				log.Fatalf("diff: %v", err)
+			}
+			d := tiles.DiffTurns(units, from, to)
+			if err := writeDiff(os.Stdout, d, argsDiff.from, argsDiff.to, argsDiff.json); err != nil {
+				log.Fatalf("diff: %v", err)
+			}
+			if argsDiff.png != "" {
+				if err := writeDiffPng(argsDiff.png, tiles.BuildThrough(units, to), d, argsDiff.hexSize); err != nil {
+					log.Fatalf("diff: %v", err)
+				}
+				log.Printf("diff: %s: created\n", argsDiff.png)
+			}
+		},
+	}
+)
+
+// writeDiff writes the changes as text or JSON.
+func writeDiff(w io.Writer, d *tiles.Diff_t, from, to string, asJSON bool) error {
+	if asJSON {
+		data, err := json.MarshalIndent(d, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "changes from %s to %s: %d\n", from, to, len(d.Changes)); err != nil {
+		return err
+	}
+	for _, c := range d.Changes {
+		if _, err := fmt.Fprintf(w, "  %s\n", c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeDiffPng draws the map with the changed tiles outlined.
+// The view covers every tile on the map.
+func writeDiffPng(path string, m *tiles.Map_t, d *tiles.Diff_t, hexSize int) error {
+	v, ok := render.ViewOfMap(m, 1)
+	if !ok {
+		return fmt.Errorf("map is empty")
+	}
+	fd, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = render.PNGDiff(fd, m, d, v, render.PNGOptions_t{HexSize: hexSize})
+	if cerr := fd.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
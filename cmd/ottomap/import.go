@@ -3,23 +3,38 @@
 package main
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
 	"github.com/playbymail/tribal"
 	"github.com/playbymail/tribal/adapters"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/docx"
+	"github.com/playbymail/tribal/mailbox"
 	"github.com/playbymail/tribal/parser"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/section"
 	"github.com/playbymail/tribal/stdlib"
 	"github.com/playbymail/tribal/store"
+	"github.com/playbymail/tribal/terrain"
+	"github.com/playbymail/tribal/text"
 	"github.com/spf13/cobra"
+	"io"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
 var (
 	argsImport struct {
 		database string // path to the database file
+		link     bool   // link files with the same contents as an existing report instead of failing
 	}
 
 	cmdImport = &cobra.Command{
@@ -31,10 +46,53 @@ var (
 		path string // path to the report file
 	}
 
+	argsImportMail struct {
+		paths []string // paths to the mailbox or message files
+	}
+
+	cmdImportMail = &cobra.Command{
+		Use:   "mail [file...]",
+		Short: "import reports attached to saved email",
+		Long: `Import the reports attached to saved email into the database.
+
+Each file may be a single message (.eml) or an mbox file. Attachments that
+match the YYYY-MM.CLAN.report pattern are imported with the clan and turn
+from the name. Other attachments are parsed, and if they have a turn line,
+the clan and turn are taken from the report. Zip attachments are opened and
+the reports in them imported. Nothing is fetched from a mail server.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if argsImport.database == "" {
+				return errors.New("database is required")
+			}
+			argsImportMail.paths = append(argsImportMail.paths, args...)
+			if len(argsImportMail.paths) == 0 {
+				return errors.New("at least one mail file is required")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			started := time.Now()
+			s, err := store.Open(argsImport.database, context.Background())
+			if err != nil {
+				log.Fatalf("error opening database: %v", err)
+			}
+			defer s.Close()
+
+			for _, path := range argsImportMail.paths {
+				imported, skipped, err := runImportMail(s, path, false)
+				if err != nil {
+					log.Fatalf("error importing mail: %v", err)
+				}
+				log.Printf("import: mail: %s: %d imported, %d skipped\n", path, imported, skipped)
+			}
+			log.Printf("import: mail: done in %v\n", time.Since(started))
+		},
+	}
+
 	cmdImportReport = &cobra.Command{
 		Use:   "report",
 		Short: "import report into the database",
-		Long:  "import report into the database",
+		Long:  "import report into the database.\nA zip archive imports every report in it, in turn order.",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
 			if argsImport.database == "" {
 				return errors.New("database is required")
@@ -51,22 +109,46 @@ var (
 				log.Fatalf("file does not exist: %s", argsImportReport.path)
 			}
 
-			// check that the file is a report that matches the YYYY-MM.CLAN.report.(docx|txt) format.
-			clan, turn, ok := adapters.ReportFileNameToClanTurn(argsImportReport.path)
-			if !ok {
-				log.Fatalf("import: invalid report name: %s", argsImportReport.path)
-			}
-
 			// use the clan from the command line if provided.
 			// otherwise, use the clan from the file name.
 			// this is a hack to support the GM who will import reports from multiple clans.
+			var clanOverride tribal.ClanId_t
 			if argsImportReport.clan != 0 {
-				log.Printf("import: clan: overriding %d: %d\n", clan, argsImportReport.clan)
-				clan, ok = adapters.IntToClanId(argsImportReport.clan)
-				if !ok {
+				var ok bool
+				if clanOverride, ok = adapters.IntToClanId(argsImportReport.clan); !ok {
 					log.Fatalf("import: invalid clan: %d", argsImportReport.clan)
 				}
 			}
+
+			// archives hold many reports, each with its own clan and turn.
+			if isZipArchive(argsImportReport.path) {
+				s, err := store.Open(argsImport.database, context.Background())
+				if err != nil {
+					log.Fatalf("error opening database: %v", err)
+				}
+				defer s.Close()
+				data, err := os.ReadFile(argsImportReport.path)
+				if err != nil {
+					log.Fatalf("error reading archive: %v", err)
+				}
+				imported, skipped, err := runImportZip(s, argsImportReport.path, data, clanOverride, true)
+				if err != nil {
+					log.Fatalf("error importing archive: %v", err)
+				}
+				log.Printf("import: zip: %s: %d imported, %d skipped in %v\n", argsImportReport.path, imported, skipped, time.Since(started))
+				return
+			}
+
+			// check that the file is a report that matches the YYYY-MM.CLAN.report.(docx|doc|odt|rtf|txt) format.
+			clan, turn, ok := adapters.ReportFileNameToClanTurn(argsImportReport.path)
+			if !ok {
+				log.Fatalf("import: invalid report name: %s", argsImportReport.path)
+			}
+
+			if clanOverride != 0 {
+				log.Printf("import: clan: overriding %d: %d\n", clan, clanOverride)
+				clan = clanOverride
+			}
 			year, month := turn.YearMonth()
 			log.Printf("import: clan %04d: turn %04d-%02d (#%d)\n", clan, year, month, turn)
 
@@ -84,6 +166,195 @@ var (
 	}
 )
 
+// runImportMail imports the reports attached to the messages in a mailbox or .eml file.
+// Attachments are reports if their name matches the report naming pattern or if
+// the parser finds a turn and a unit in them. Duplicate reports are skipped, since
+// mailboxes often have the same report more than once.
+//
+// Reports are saved under the mailbox path, the message number, and the
+// attachment name (e.g. "inbox.mbox/message-3/0900-05.0987.report.docx"),
+// so they can be traced back to the message they came from.
+func runImportMail(s *store.Store, path string, debug bool) (imported, skipped int, err error) {
+	attachments, err := mailbox.ReadFile(path)
+	if err != nil && len(attachments) == 0 {
+		return 0, 0, err
+	} else if err != nil {
+		log.Printf("import: mail: %s: %v\n", path, err)
+	}
+	for _, a := range attachments {
+		name := mailAttachmentName(path, a.Message, a.Name)
+		if isZipArchive(a.Name) {
+			n, m, err := runImportZip(s, name, a.Data, 0, debug)
+			if err != nil {
+				return imported, skipped, fmt.Errorf("%s: %w", name, err)
+			}
+			imported, skipped = imported+n, skipped+m
+			continue
+		}
+		clan, turn, ok := adapters.ReportFileNameToClanTurn(a.Name)
+		if !ok {
+			if clan, turn, ok = reportContentToClanTurn(a.Name, a.Data); ok {
+				// save it under the name it should have had
+				year, month := turn.YearMonth()
+				log.Printf("import: mail: %s: not a report name, using clan %04d turn %04d-%02d from the contents\n", name, clan, year, month)
+				name = mailAttachmentName(path, a.Message, fmt.Sprintf("%04d-%02d.%04d.report.%s", year, month, clan, reportExtension(a.Data)))
+			}
+		}
+		if !ok {
+			log.Printf("import: mail: %s: not a report\n", name)
+			skipped++
+			continue
+		}
+		year, month := turn.YearMonth()
+		log.Printf("import: mail: %s: clan %04d: turn %04d-%02d (#%d)\n", name, clan, year, month, turn)
+		if err := runImportReportData(s, clan, turn, name, a.Data, debug); errors.Is(err, store.ErrDuplicateReport) {
+			skipped++
+			continue
+		} else if err != nil {
+			return imported, skipped, fmt.Errorf("%s: %w", name, err)
+		}
+		imported++
+	}
+	return imported, skipped, nil
+}
+
+// mailAttachmentName returns the name that an attachment is saved under.
+func mailAttachmentName(path string, message int, name string) string {
+	return fmt.Sprintf("%s/message-%d/%s", path, message, name)
+}
+
+// isZipArchive returns true if the path names a zip archive of reports.
+// We go by the extension since Word and OpenDocument files are zip files, too.
+func isZipArchive(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".zip")
+}
+
+// maxZipEntrySize is the largest report that we'll read from an archive.
+// Larger entries are skipped so that a damaged or hostile archive can't
+// exhaust memory.
+var maxZipEntrySize int64 = 32 * 1024 * 1024
+
+// runImportZip imports the reports in a zip archive. The archive is read in
+// memory. Entries must match the report naming pattern; anything else is
+// skipped, as are duplicates and entries larger than maxZipEntrySize.
+// Reports are imported in turn order and saved under the archive path
+// followed by the entry name.
+//
+// If clan is not zero, it replaces the clan from the entry names.
+func runImportZip(s *store.Store, path string, data []byte, clan tribal.ClanId_t, debug bool) (imported, skipped int, err error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return 0, 0, err
+	}
+	type entry_t struct {
+		file *zip.File
+		clan tribal.ClanId_t
+		turn tribal.TurnId_t
+	}
+	var entries []entry_t
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+		entryClan, turn, ok := adapters.ReportFileNameToClanTurn(f.Name)
+		if !ok {
+			log.Printf("import: zip: %s: %s: not a report name\n", path, f.Name)
+			skipped++
+			continue
+		} else if f.UncompressedSize64 > uint64(maxZipEntrySize) {
+			log.Printf("import: zip: %s: %s: %d bytes: larger than %d\n", path, f.Name, f.UncompressedSize64, maxZipEntrySize)
+			skipped++
+			continue
+		}
+		if clan != 0 {
+			entryClan = clan
+		}
+		entries = append(entries, entry_t{file: f, clan: entryClan, turn: turn})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].turn != entries[j].turn {
+			return entries[i].turn < entries[j].turn
+		} else if entries[i].clan != entries[j].clan {
+			return entries[i].clan < entries[j].clan
+		}
+		return entries[i].file.Name < entries[j].file.Name
+	})
+
+	for _, e := range entries {
+		name := path + "/" + e.file.Name
+		rdr, err := e.file.Open()
+		if err != nil {
+			return imported, skipped, fmt.Errorf("%s: %w", name, err)
+		}
+		// the size in the header can lie, so don't read more than we allow
+		data, err := io.ReadAll(io.LimitReader(rdr, maxZipEntrySize+1))
+		_ = rdr.Close()
+		if err != nil {
+			return imported, skipped, fmt.Errorf("%s: %w", name, err)
+		} else if int64(len(data)) > maxZipEntrySize {
+			log.Printf("import: zip: %s: larger than %d\n", name, maxZipEntrySize)
+			skipped++
+			continue
+		}
+		year, month := e.turn.YearMonth()
+		log.Printf("import: zip: %s: clan %04d: turn %04d-%02d (#%d)\n", name, e.clan, year, month, e.turn)
+		if err := runImportReportData(s, e.clan, e.turn, name, data, debug); errors.Is(err, store.ErrDuplicateReport) {
+			skipped++
+			continue
+		} else if err != nil {
+			return imported, skipped, fmt.Errorf("%s: %w", name, err)
+		}
+		imported++
+	}
+	return imported, skipped, nil
+}
+
+// reportContentToClanTurn is the fallback for attachments with mangled names.
+// It parses the attachment and takes the turn from the turn line and the
+// clan from the first unit in the report.
+func reportContentToClanTurn(name string, data []byte) (clan tribal.ClanId_t, turn tribal.TurnId_t, ok bool) {
+	if docx.DetectWordDocType(data) == docx.Unknown {
+		// plain text must look like a report before we bother parsing it
+		if !bytes.Contains(bytes.ToLower(text.Decode(data)), []byte("current turn")) {
+			return 0, 0, false
+		}
+	}
+	rpt, err := parser.Report(name, parser.WithData(data))
+	if err != nil || rpt == nil || rpt.Turn == nil || len(rpt.Units) == 0 {
+		return 0, 0, false
+	}
+	if turn, ok = adapters.YearMonthToTurnId(rpt.Turn.Year, rpt.Turn.Month); !ok {
+		return 0, 0, false
+	}
+	id := string(rpt.Units[0].Id)
+	if len(id) < 4 {
+		return 0, 0, false
+	}
+	n, err := strconv.Atoi(id[:4])
+	if err != nil {
+		return 0, 0, false
+	}
+	if clan, ok = adapters.IntToClanId(n % 1000); !ok {
+		return 0, 0, false
+	}
+	return clan, turn, true
+}
+
+// reportExtension returns the file extension for the type of the report.
+func reportExtension(data []byte) string {
+	switch docx.DetectWordDocType(data) {
+	case docx.Doc:
+		return "doc"
+	case docx.Docx:
+		return "docx"
+	case docx.Odt:
+		return "odt"
+	case docx.Rtf:
+		return "rtf"
+	}
+	return "txt"
+}
+
 // runImportReport imports a report into the database.
 // It returns an error if the report is not unique.
 // It should update the database with a single transaction.
@@ -92,6 +363,12 @@ func runImportReport(s *store.Store, clan tribal.ClanId_t, turn tribal.TurnId_t,
 	if err != nil {
 		return err
 	}
+	return runImportReportData(s, clan, turn, path, data, debug)
+}
+
+// runImportReportData imports the contents of a report into the database.
+// Path is the name the report is saved under. It doesn't have to exist on disk.
+func runImportReportData(s *store.Store, clan tribal.ClanId_t, turn tribal.TurnId_t, path string, data []byte, debug bool) error {
 	hash := store.Hash(data)
 	if row, err := s.GetReportByHash(hash); err != nil {
 		log.Printf("error: %v\n", err)
@@ -101,7 +378,6 @@ func runImportReport(s *store.Store, clan tribal.ClanId_t, turn tribal.TurnId_t,
 		log.Printf("       created at: %s\n", row.CreatedAt.Format(time.RFC3339))
 		return store.ErrDuplicateReport
 	}
-	log.Printf("import: report: %s: seems unique\n", path)
 
 	rpt, err := parser.Report(path, parser.WithDebug(debug), parser.WithData(data))
 	if err != nil {
@@ -110,6 +386,26 @@ func runImportReport(s *store.Store, clan tribal.ClanId_t, turn tribal.TurnId_t,
 		log.Printf("import: report: %s: parser returned nil\n", path)
 		return nil
 	}
+
+	// the hash changes when the report is re-saved in Word or converted to text,
+	// so we also compare the normalized contents before importing it again.
+	fingerprint := section.Fingerprint(section.New(section.DefaultConfig()).Split(bytes.Join(rpt.Lines, []byte{'\n'})))
+	if row, err := s.GetReportByFingerprint(fingerprint); err != nil {
+		log.Printf("error: %v\n", err)
+	} else if row != nil && !argsImport.link {
+		log.Printf("error: report with same contents already exists\n")
+		log.Printf("       name: %s\n", row.Name)
+		log.Printf("       created at: %s\n", row.CreatedAt.Format(time.RFC3339))
+		log.Printf("       use --link to link this file to the existing report\n")
+		return store.ErrDuplicateReport
+	} else if row != nil {
+		if err := s.LinkReport(row.Id, hash, path); err != nil {
+			return err
+		}
+		log.Printf("import: report: %s: same contents as %s: linked to report %d\n", path, row.Name, row.Id)
+		return nil
+	}
+	log.Printf("import: report: %s: seems unique\n", path)
 	log.Printf("import: report: %s: %s\n", path, rpt.Hash)
 	if rpt.Turn == nil {
 		rpt.Turn = &parser.Turn_t{
@@ -127,15 +423,145 @@ func runImportReport(s *store.Store, clan tribal.ClanId_t, turn tribal.TurnId_t,
 
 	// adapt from parser report to domain report
 	drpt := tribal.ReportFile_t{
-		Owner: clan,
-		Turn:  turn,
-		Hash:  rpt.Hash,
+		Owner:       clan,
+		Name:        path,
+		Turn:        turn,
+		Hash:        rpt.Hash,
+		Original:    data,
+		Fingerprint: fingerprint,
 	}
+	for n, line := range rpt.Lines {
+		drpt.Lines = append(drpt.Lines, &tribal.ReportLine_t{
+			No:     n + 1,
+			Source: rpt.Sources[n],
+			Text:   string(line),
+		})
+	}
+
+	// the section parsers extract the people and goods blocks
+	drpt.Units = importUnits(path, rpt.Lines, debug)
 
-	// this should be committed as a single transaction
-	if err := s.CreateReport(&drpt); err != nil {
+	// this is committed as a single transaction
+	id, err := s.CreateReport(&drpt)
+	if err != nil {
 		return err
 	}
+	log.Printf("import: report: %s: report %d: %d lines\n", path, id, len(drpt.Lines))
 
 	return nil
 }
+
+// importUnits runs the section parsers on the normalized lines of the report
+// and adapts the units they find to the domain model.
+func importUnits(path string, lines [][]byte, debug bool) (units []*tribal.Unit_t) {
+	p := section.New(section.DefaultConfig())
+	if !debug {
+		p.Log = log.New(io.Discard, "", 0)
+	}
+	for _, s := range p.Parse(path, bytes.Join(lines, []byte{'\n'})) {
+		if s.Unit == nil {
+			log.Printf("import: report: %s: section %d: %v\n", path, s.Id, s.Error)
+			continue
+		}
+		unit := &tribal.Unit_t{
+			Id:    tribal.UnitId_t(s.Unit.Id),
+			Name:  tribal.UnitName_t(s.Unit.Name),
+			Error: s.Error,
+		}
+		unit.Moves = importMoves(s.Unit)
+		if inv := s.Unit.Inventory; inv != nil {
+			unit.Inventory = &tribal.Inventory_t{Unit: unit.Id}
+			if inv.Turn != nil {
+				unit.Inventory.Turn = tribal.TurnId_t(inv.Turn.Id)
+			}
+			if pop := inv.Population; pop != nil {
+				unit.Inventory.Population = &tribal.Population_t{
+					People:    pop.People,
+					Warriors:  pop.Warriors,
+					Actives:   pop.Actives,
+					Inactives: pop.Inactives,
+				}
+			}
+			for _, item := range inv.Items {
+				unit.Inventory.Items = append(unit.Inventory.Items, &tribal.Item_t{
+					Code:     strings.ToUpper(item.Item.String()),
+					Quantity: item.Quantity,
+				})
+			}
+			if inv.Errors != nil {
+				for _, excess := range inv.Errors.ExcessInput {
+					log.Printf("import: report: %s: unit %s: inventory: unknown %q\n", path, unit.Id, excess)
+				}
+			}
+		}
+		units = append(units, unit)
+	}
+	return units
+}
+
+// importMoves adapts the unit's marches and its scouts' patrols to the domain model.
+// Each scout is its own unit, so its steps are numbered separately from the tribe's.
+// Steps that only report what was found, and steps that aren't on the map, are skipped.
+func importMoves(u *ast.Unit_t) (moves []*tribal.Move_t) {
+	if u.Moves == nil {
+		return nil
+	}
+	steps := map[tribal.UnitId_t]int{}
+	add := func(id tribal.UnitId_t, from ast.Coordinates_t, d direction.Direction_e, to ast.Coordinates_t, ter terrain.Terrain_e, failure ast.MoveFailure_e, neighbors []*ast.Neighbor_t, borders []*ast.Border_t) {
+		if failure != ast.NoFailure {
+			// the failed step names the direction the unit tried to move in
+			d = failedDirection(neighbors, borders)
+		}
+		if d == direction.None && failure == ast.NoFailure {
+			return
+		} else if from.IsZero() || to.IsZero() {
+			return
+		}
+		action := direction.EnumToString[d]
+		if d == direction.None {
+			action = "STILL"
+		}
+		code := terrain.EnumToString[ter]
+		if ter == terrain.Blank {
+			code = "*"
+		}
+		steps[id]++
+		moves = append(moves, &tribal.Move_t{
+			Unit:    id,
+			Step:    steps[id],
+			From:    importCoordinates(from),
+			Action:  action,
+			To:      importCoordinates(to),
+			Terrain: code,
+			Failure: ast.MoveFailureToString[failure],
+		})
+	}
+	for _, m := range u.Moves.Marches {
+		add(tribal.UnitId_t(u.Id), m.From, m.Direction, m.To, m.Terrain, m.Failure, m.Neighbors, m.Borders)
+	}
+	for _, p := range u.Moves.Patrols {
+		id := tribal.UnitId_t(fmt.Sprintf("%ss%d", u.Id, p.Patrol))
+		add(id, p.From, p.Direction, p.To, p.Terrain, p.Failure, p.Neighbors, p.Borders)
+	}
+	return moves
+}
+
+// importCoordinates adapts the coordinates to the domain model.
+func importCoordinates(c ast.Coordinates_t) tribal.Coordinates_t {
+	return tribal.Coordinates_t{Grid: c.String()[:2], Col: c.Column, Row: c.Row}
+}
+
+// failedDirection returns the direction of the neighbor or border named by a failed step.
+func failedDirection(neighbors []*ast.Neighbor_t, borders []*ast.Border_t) direction.Direction_e {
+	for _, n := range neighbors {
+		if len(n.Direction) != 0 {
+			return n.Direction[0]
+		}
+	}
+	for _, b := range borders {
+		if len(b.Direction) != 0 {
+			return b.Direction[0]
+		}
+	}
+	return direction.None
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/playbymail/tribal"
+	"github.com/playbymail/tribal/border"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/store"
+	"github.com/playbymail/tribal/terrain"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestImportMoves(t *testing.T) {
+	kp0608 := ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8}
+	kp0709 := ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 7, Row: 9}
+	unit := &ast.Unit_t{
+		Id: "0987",
+		Moves: &ast.Moves_t{
+			Marches: []*ast.March_t{
+				{From: kp0608, Direction: direction.SouthEast, To: kp0709, Terrain: terrain.Prairie},
+				{From: kp0709, To: kp0709, Terrain: terrain.Prairie, Failure: ast.NotEnoughMPs,
+					Neighbors: []*ast.Neighbor_t{{Terrain: terrain.GrassyHills, Direction: []direction.Direction_e{direction.South}}}},
+			},
+			Patrols: []*ast.Patrol_t{
+				{Patrol: 3, From: kp0608, To: kp0608, Terrain: terrain.Prairie, Failure: ast.NoFord,
+					Borders: []*ast.Border_t{{Border: border.River, Direction: []direction.Direction_e{direction.SouthEast}}}},
+				// nothing of interest found isn't a move
+				{Patrol: 3, From: kp0608, To: kp0608, Terrain: terrain.Prairie},
+			},
+		},
+	}
+
+	from := tribal.Coordinates_t{Grid: "KP", Col: 6, Row: 8}
+	to := tribal.Coordinates_t{Grid: "KP", Col: 7, Row: 9}
+	want := []tribal.Move_t{
+		{Unit: "0987", Step: 1, From: from, Action: "SE", To: to, Terrain: "PR"},
+		{Unit: "0987", Step: 2, From: to, Action: "S", To: to, Terrain: "PR", Failure: "NOT_ENOUGH_MPS"},
+		{Unit: "0987s3", Step: 1, From: from, Action: "SE", To: from, Terrain: "PR", Failure: "NO_FORD"},
+	}
+	got := importMoves(unit)
+	if len(got) != len(want) {
+		t.Fatalf("moves: want %d, got %d", len(want), len(got))
+	}
+	for n := range want {
+		if *got[n] != want[n] {
+			t.Errorf("move %d: want %+v, got %+v", n+1, want[n], *got[n])
+		}
+	}
+}
+
+func TestRunImportReportDataFingerprint(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("..", "..", "section", "testdata", "scouts.report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the same report after a trip through an editor that changed the line endings and spacing
+	crlf := bytes.ReplaceAll(data, []byte("\n"), []byte("\r\n"))
+	spaced := append([]byte("\n  \n"), bytes.ReplaceAll(data, []byte("\n"), []byte("  \n\n"))...)
+
+	defer func(link bool) { argsImport.link = link }(argsImport.link)
+	s := newTestStore(t)
+	if err := runImportReportData(s, 987, 5, "0900-05.0987.report.txt", data, false); err != nil {
+		t.Fatal(err)
+	}
+	rpt, err := s.GetReportByHash(store.Hash(data))
+	if err != nil || rpt == nil {
+		t.Fatalf("report: want report, got %v, %v", rpt, err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{"crlf.report.txt", crlf},
+		{"spaced.report.txt", spaced},
+	} {
+		argsImport.link = false
+		if err := runImportReportData(s, 987, 5, tc.name, tc.data, false); !errors.Is(err, store.ErrDuplicateReport) {
+			t.Errorf("%s: want %v, got %v", tc.name, store.ErrDuplicateReport, err)
+		}
+		argsImport.link = true
+		if err := runImportReportData(s, 987, 5, tc.name, tc.data, false); err != nil {
+			t.Fatalf("%s: link: %v", tc.name, err)
+		}
+		if row, err := s.GetReportByHash(store.Hash(tc.data)); err != nil {
+			t.Fatal(err)
+		} else if row == nil || row.Id != rpt.Id {
+			t.Errorf("%s: want link to report %d, got %+v", tc.name, rpt.Id, row)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/lint"
+	"github.com/playbymail/tribal/norm"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	argsLint struct {
+		path      string   // path to the report file
+		output    string   // path to the corrected report
+		disable   []string // rules to skip
+		diff      bool     // write a unified diff to stdout
+		listRules bool     // list the rules and exit
+	}
+
+	cmdLint = &cobra.Command{
+		Use:   "lint",
+		Short: "report and fix punctuation mistakes in a turn report",
+		Long: `Report and fix the punctuation mistakes in a turn report.
+
+Every change is listed with its line number and the name of the rule
+that made it. The corrected report is written as plain text.
+
+The other rules need the spaces fixed first, so disabling the spaces
+rule leaves the lines with spacing mistakes unchanged.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if argsLint.listRules {
+				return nil
+			} else if argsLint.path == "" {
+				return fmt.Errorf("file is required")
+			}
+			known := map[string]bool{}
+			for _, rule := range norm.Rules() {
+				known[rule.Name] = true
+			}
+			for _, name := range argsLint.disable {
+				if !known[name] {
+					return fmt.Errorf("unknown rule %q", name)
+				}
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			if argsLint.listRules {
+				for _, rule := range norm.Rules() {
+					fmt.Printf("%-20s  %s\n", rule.Name, rule.Descr)
+				}
+				return
+			}
+			output := argsLint.output
+			if output == "" {
+				output = strings.TrimSuffix(argsLint.path, filepath.Ext(argsLint.path)) + ".lint.txt"
+			}
+			disabled := map[string]bool{}
+			for _, name := range argsLint.disable {
+				disabled[name] = true
+			}
+			if err := runLint(os.Stdout, argsLint.path, output, disabled, argsLint.diff); err != nil {
+				log.Fatalf("lint: %v", err)
+			}
+		},
+	}
+)
+
+// runLint lints the report, writes the corrected report to output,
+// and writes the list of changes (and optionally the diff) to w.
+func runLint(w io.Writer, path, output string, disabled map[string]bool, diff bool) error {
+	input, err := readReportText(path)
+	if err != nil {
+		return err
+	}
+	r := lint.Lint(input, disabled)
+	for _, hit := range r.Hits {
+		_, _ = fmt.Fprintf(w, "%s:%d: %s: %s\n", path, hit.Line, hit.Rule.Name, hit.Rule.Descr)
+	}
+	if err := os.WriteFile(output, r.Text(), 0644); err != nil {
+		return err
+	}
+	log.Printf("lint: %s: %d changes: wrote %s\n", path, len(r.Hits), output)
+	if diff {
+		_, _ = w.Write(r.UnifiedDiff(path, output, 3))
+	}
+	return nil
+}
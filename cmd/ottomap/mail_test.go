@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"github.com/playbymail/tribal/store"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunImportMail(t *testing.T) {
+	turn5, err := os.ReadFile(filepath.Join("..", "..", "section", "testdata", "scouts.report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	turn4 := bytes.Replace(turn5, []byte("900-05 (#5)"), []byte("900-04 (#4)"), 1)
+	archive := newTestZip(t, "0900-04.0987.report.txt", string(turn4))
+
+	// message 1 has a report with a mangled name, message 2 has an archive
+	mbox := &bytes.Buffer{}
+	for n, a := range []struct {
+		name string
+		data []byte
+	}{
+		{"Turn report.txt", turn5},
+		{"reports.zip", archive},
+	} {
+		fmt.Fprintf(mbox, "From gm@example.com Sat Jan  4 12:00:00 2025\n")
+		fmt.Fprintf(mbox, "From: GM <gm@example.com>\nSubject: message %d\nMIME-Version: 1.0\n", n+1)
+		fmt.Fprintf(mbox, "Content-Type: multipart/mixed; boundary=\"b\"\n\n--b\n")
+		fmt.Fprintf(mbox, "Content-Type: application/octet-stream\nContent-Disposition: attachment; filename=%q\n", a.name)
+		fmt.Fprintf(mbox, "Content-Transfer-Encoding: base64\n\n%s\n--b--\n\n", base64.StdEncoding.EncodeToString(a.data))
+	}
+	path := filepath.Join(t.TempDir(), "inbox.mbox")
+	if err := os.WriteFile(path, mbox.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newTestStore(t)
+	imported, skipped, err := runImportMail(s, path, false)
+	if err != nil {
+		t.Fatal(err)
+	} else if imported != 2 || skipped != 0 {
+		t.Errorf("imported, skipped: want 2, 0, got %d, %d", imported, skipped)
+	}
+
+	for _, tc := range []struct {
+		data []byte
+		name string
+	}{
+		{turn5, path + "/message-1/0900-05.0987.report.txt"},
+		{turn4, path + "/message-2/reports.zip/0900-04.0987.report.txt"},
+	} {
+		rpt, err := s.GetReportByHash(store.Hash(tc.data))
+		if err != nil {
+			t.Fatal(err)
+		} else if rpt == nil {
+			t.Errorf("%s: not imported", tc.name)
+		} else if rpt.Name != tc.name {
+			t.Errorf("name: want %q, got %q", tc.name, rpt.Name)
+		}
+	}
+}
@@ -10,26 +10,46 @@ import (
 	"github.com/playbymail/tribal"
 	"github.com/playbymail/tribal/adapters"
 	"github.com/playbymail/tribal/docx"
+	"github.com/playbymail/tribal/parser/ast"
 	"github.com/playbymail/tribal/section"
+	"github.com/playbymail/tribal/text"
 	"github.com/spf13/cobra"
 	"log"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
 func main() {
+	// sub-commands are handled by cobra; flags are the legacy import
+	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
+		if err := runCobra(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
-	flag.BoolVar(&section.DebugConfig.SplitTurns, "split-turns", false, "Enable splitting of turns")
-	flag.BoolVar(&section.DebugConfig.SplitFollows, "split-follows", false, "Enable splitting of follows")
-	flag.BoolVar(&section.DebugConfig.SplitGoesTo, "split-goes-to", false, "Enable splitting of goesTo")
-	flag.BoolVar(&section.DebugConfig.SplitMarches, "split-marches", false, "Enable splitting of marches")
-	flag.BoolVar(&section.DebugConfig.SplitSails, "split-sails", false, "Enable splitting of sails")
-	flag.BoolVar(&section.DebugConfig.SplitPatrols, "split-patrols", false, "Enable splitting of patrols")
-	flag.BoolVar(&section.DebugConfig.SplitStatus, "split-status", false, "Enable splitting of status")
+	var cfg section.Config_t
+	flag.BoolVar(&cfg.SplitTurns, "split-turns", false, "Enable splitting of turns")
+	flag.BoolVar(&cfg.SplitFollows, "split-follows", false, "Enable splitting of follows")
+	flag.BoolVar(&cfg.SplitGoesTo, "split-goes-to", false, "Enable splitting of goesTo")
+	flag.BoolVar(&cfg.SplitMarches, "split-marches", false, "Enable splitting of marches")
+	flag.BoolVar(&cfg.SplitSails, "split-sails", false, "Enable splitting of sails")
+	flag.BoolVar(&cfg.SplitPatrols, "split-patrols", false, "Enable splitting of patrols")
+	flag.BoolVar(&cfg.SplitStatus, "split-status", false, "Enable splitting of status")
 
 	flag.Parse()
 
+	// collect the units from every section that parsed
+	var units []*ast.Unit_t
+	p := section.New(cfg)
+	p.Sink = func(s *section.Section) {
+		if s.Error == nil {
+			units = append(units, s.Unit)
+		}
+	}
+
 	log.SetFlags(log.Lshortfile)
 
 	var started time.Time
@@ -43,7 +63,7 @@ func main() {
 	started = time.Now()
 	for _, clan := range []int{138} {
 		for _, turnId := range []tribal.TurnId_t{0, 1, 2, 3, 4, 5} {
-			if err := importWord(".", clan, turnId); err != nil {
+			if err := importWord(p, ".", clan, turnId); err != nil {
 				log.Print(err)
 				log.SetOutput(os.Stderr)
 				log.Fatal(err)
@@ -59,8 +79,8 @@ func main() {
 		log.SetOutput(fd)
 	}
 	started = time.Now()
-	section.Units = nil
-	if err := importPlainText(".", 999, 1200); err != nil {
+	units = nil
+	if err := importPlainText(p, ".", 999, 1200); err != nil {
 		log.Print(err)
 		log.SetOutput(os.Stderr)
 		log.Fatal(err)
@@ -68,7 +88,7 @@ func main() {
 	log.Printf("ottomap: completed in %v", time.Since(started))
 	log.SetOutput(os.Stderr)
 
-	if buf, err := json.MarshalIndent(section.Units, "", "  "); err != nil {
+	if buf, err := json.MarshalIndent(units, "", "  "); err != nil {
 		log.Fatal(err)
 	} else if err = os.WriteFile("0999-12.parser.json", buf, 0644); err != nil {
 		log.Fatal(err)
@@ -77,7 +97,7 @@ func main() {
 	}
 }
 
-func importPlainText(path string, clan int, turnId tribal.TurnId_t) error {
+func importPlainText(p *section.Parser, path string, clan int, turnId tribal.TurnId_t) error {
 	started := time.Now()
 	defer log.Printf("import: plain text: completed in %v", time.Since(started))
 
@@ -94,10 +114,10 @@ func importPlainText(path string, clan int, turnId tribal.TurnId_t) error {
 		return err
 	}
 
-	return importReport(clan, turnId, input)
+	return importReport(p, clan, turnId, text.Decode(input))
 }
 
-func importWord(path string, clan int, turnId tribal.TurnId_t) error {
+func importWord(p *section.Parser, path string, clan int, turnId tribal.TurnId_t) error {
 	started := time.Now()
 	defer log.Printf("import: word: completed in %v", time.Since(started))
 
@@ -115,10 +135,10 @@ func importWord(path string, clan int, turnId tribal.TurnId_t) error {
 	}
 	log.Printf("%s: %4d lines in %v", reportName, len(lines), time.Since(started))
 
-	return importReport(clan, turnId, bytes.Join(lines, []byte{'\n'}))
+	return importReport(p, clan, turnId, bytes.Join(lines, []byte{'\n'}))
 }
 
-func importReport(clan int, turnId tribal.TurnId_t, input []byte) error {
+func importReport(p *section.Parser, clan int, turnId tribal.TurnId_t, input []byte) error {
 	started := time.Now()
 	defer log.Printf("import: report: completed in %v", time.Since(started))
 
@@ -130,30 +150,33 @@ func importReport(clan int, turnId tribal.TurnId_t, input []byte) error {
 
 	log.Printf("import: %04d: %04d-%02d (#%d) bytes %d\n", clan, turnYear, turnMonth, turnId, len(input))
 
-	// split the input into sections
+	// split the input into sections and parse them, returning the map and all errors
 	now := time.Now()
-	sections := section.Split(input)
-	log.Printf("sectioned                  %8d bytes into %8d sections in %v", len(input), len(sections), time.Since(now))
+	sections := p.Parse(reportId, input)
+	for _, s := range sections {
+		if s.Error != nil {
+			log.Printf("section: %d: %s: %v", s.Id, s.Lines.Unit, s.Error)
+		}
+	}
+	log.Printf("parsed                     %8d bytes into %8d sections in %v", len(input), len(sections), time.Since(now))
 
 	now = time.Now()
 	if err := dumpSections(sections, fmt.Sprintf("%04d-%02d.%04d.sections.txt", turnYear, turnMonth, clan), true); err != nil {
 		return err
 	}
-	log.Printf("dumped                     %8d sections in %v", len(sections), time.Since(now))
-
-	// parse the sections, returning the map and all errors
-	now = time.Now()
-	for _, s := range sections {
-		if err := s.Parse(reportId); err != nil {
-			log.Printf("section: %d: %s: %v", s.Id, s.Lines.Unit, err)
-		}
-	}
-	log.Printf("parsed                     %8d sections in %v\n\n\n", len(sections), time.Since(now))
+	log.Printf("dumped                     %8d sections in %v\n\n\n", len(sections), time.Since(now))
 
 	return nil
 }
 
 func runCobra() error {
+	cmdRoot.PersistentFlags().StringVar(&argsRoot.cacheDir, "cache-dir", "", "path to the cache of parsed reports (default is the user cache directory)")
+	cmdRoot.PersistentFlags().BoolVar(&argsRoot.noCache, "no-cache", false, "always parse the reports")
+
+	cmdRoot.AddCommand(cmdConflicts)
+	cmdConflicts.Flags().StringSliceVarP(&argsConflicts.paths, "file", "p", nil, "path to a report file (may be repeated)")
+	cmdConflicts.Flags().BoolVar(&argsConflicts.json, "json", false, "write the conflicts as JSON")
+
 	cmdRoot.AddCommand(cmdCreate)
 	cmdCreate.PersistentFlags().StringVarP(&argsCreate.database, "database", "D", "tribal.sqlite", "path to the database file")
 
@@ -165,13 +188,70 @@ func runCobra() error {
 
 	cmdRoot.AddCommand(cmdImport)
 	cmdImport.PersistentFlags().StringVarP(&argsImport.database, "database", "D", "tribal.sqlite", "path to the database file")
+	cmdImport.PersistentFlags().BoolVar(&argsImport.link, "link", false, "link files with the same contents as an existing report to that report")
+
+	cmdImport.AddCommand(cmdImportMail)
+	cmdImportMail.Flags().StringSliceVarP(&argsImportMail.paths, "file", "p", nil, "path to an .eml or mbox file (may be repeated)")
 
 	cmdImport.AddCommand(cmdImportReport)
+	cmdImportReport.Flags().IntVar(&argsImportReport.clan, "clan", 0, "clan that owns the reports (default is the clan from the file name)")
 	cmdImportReport.Flags().StringVarP(&argsImportReport.path, "file", "p", "", "path to the report file")
 	if err := cmdImportReport.MarkFlagRequired("file"); err != nil {
 		log.Fatalf("import: report: file: %v\n", err)
 	}
 
+	cmdRoot.AddCommand(cmdLint)
+	cmdLint.Flags().StringVarP(&argsLint.path, "file", "p", "", "path to the report file")
+	cmdLint.Flags().StringVarP(&argsLint.output, "output", "o", "", "path to the corrected report (default is the report with a .lint.txt extension)")
+	cmdLint.Flags().StringSliceVar(&argsLint.disable, "disable", nil, "rules to skip")
+	cmdLint.Flags().BoolVar(&argsLint.diff, "diff", false, "write a unified diff of the changes")
+	cmdLint.Flags().BoolVar(&argsLint.listRules, "list-rules", false, "list the rules and exit")
+
+	cmdRoot.AddCommand(cmdDiff)
+	cmdDiff.Flags().StringSliceVarP(&argsDiff.paths, "file", "p", nil, "path to a report file (may be repeated)")
+	cmdDiff.Flags().StringVar(&argsDiff.from, "from", "", "turn to compare from, e.g. 901-03")
+	cmdDiff.Flags().StringVar(&argsDiff.to, "to", "", "turn to compare to, e.g. 901-04")
+	cmdDiff.Flags().BoolVar(&argsDiff.json, "json", false, "write the changes as JSON")
+	cmdDiff.Flags().StringVar(&argsDiff.png, "png", "", "path to write the map with the changes outlined")
+	cmdDiff.Flags().IntVar(&argsDiff.hexSize, "hex-size", 24, "distance from the center of a hex to a corner, in pixels")
+
+	cmdRoot.AddCommand(cmdReconcile)
+	cmdReconcile.Flags().StringSliceVarP(&argsReconcile.paths, "file", "p", nil, "path to a report file (may be repeated)")
+	cmdReconcile.Flags().BoolVar(&argsReconcile.json, "json", false, "write the suspected errors as JSON")
+
+	cmdRoot.AddCommand(cmdRender)
+	cmdRender.PersistentFlags().StringSliceVarP(&argsRender.paths, "file", "p", nil, "path to a report file (may be repeated)")
+	cmdRender.PersistentFlags().StringVar(&argsRender.center, "center", "", "coordinates to center the view on, e.g. \"KP 0608\"")
+	cmdRender.PersistentFlags().StringVar(&argsRender.grid, "grid", "", "grid or range of grids to view, e.g. \"KP\" or \"KP-LQ\"")
+	cmdRender.AddCommand(cmdRenderAscii)
+	cmdRenderAscii.Flags().IntVar(&argsRenderAscii.columns, "columns", 16, "width of the view, in tiles")
+	cmdRenderAscii.Flags().IntVar(&argsRenderAscii.rows, "rows", 10, "height of the view, in tiles")
+	cmdRenderAscii.Flags().BoolVar(&argsRenderAscii.noColor, "no-color", false, "don't use ANSI colors")
+	cmdRenderAscii.Flags().BoolVarP(&argsRenderAscii.interactive, "interactive", "i", false, "pan the view with the keyboard")
+	cmdRender.AddCommand(cmdRenderPng)
+	cmdRenderPng.Flags().StringVarP(&argsRenderPng.output, "output", "o", "", "path to write the image to")
+	cmdRenderPng.Flags().IntVar(&argsRenderPng.hexSize, "hex-size", 24, "distance from the center of a hex to a corner, in pixels")
+	cmdRenderPng.Flags().IntVar(&argsRenderPng.columns, "columns", 0, "width of the view, in tiles (default is the whole map)")
+	cmdRenderPng.Flags().IntVar(&argsRenderPng.rows, "rows", 0, "height of the view, in tiles (default is the whole map)")
+	cmdRenderPng.Flags().BoolVar(&argsRenderPng.grid, "grid-lines", false, "draw the hexes that we don't know anything about")
+	cmdRender.AddCommand(cmdRenderGif)
+	cmdRenderGif.Flags().StringVarP(&argsRenderGif.output, "output", "o", "", "path to write the animation to")
+	cmdRenderGif.Flags().IntVar(&argsRenderGif.hexSize, "hex-size", 24, "distance from the center of a hex to a corner, in pixels")
+	cmdRenderGif.Flags().IntVar(&argsRenderGif.delay, "delay", 100, "delay between frames, in hundredths of a second")
+	cmdRenderGif.Flags().BoolVar(&argsRenderGif.perStep, "per-step", false, "add a frame for every step that a unit takes")
+	cmdRenderGif.Flags().IntVar(&argsRenderGif.columns, "columns", 0, "width of the view, in tiles (default is the whole map)")
+	cmdRenderGif.Flags().IntVar(&argsRenderGif.rows, "rows", 0, "height of the view, in tiles (default is the whole map)")
+	cmdRenderGif.Flags().BoolVar(&argsRenderGif.grid, "grid-lines", false, "draw the hexes that we don't know anything about")
+
+	cmdRoot.AddCommand(cmdParsers)
+	cmdParsers.AddCommand(cmdParsersCompare)
+	cmdParsersCompare.Flags().StringVarP(&argsParsersCompare.path, "file", "p", "", "path to the report file")
+	if err := cmdParsersCompare.MarkFlagRequired("file"); err != nil {
+		log.Fatalf("parsers: compare: file: %v\n", err)
+	}
+	cmdParsersCompare.Flags().StringSliceVar(&argsParsersCompare.backends, "backends", nil, "backends to compare (default all)")
+	cmdParsersCompare.Flags().BoolVar(&argsParsersCompare.all, "all", false, "report values missing from one of the backends")
+
 	if err := cmdRoot.Execute(); err != nil {
 		log.Fatal(err)
 	}
@@ -180,6 +260,11 @@ func runCobra() error {
 }
 
 var (
+	argsRoot struct {
+		cacheDir string // path to the cache of parsed reports
+		noCache  bool   // don't load or save parsed reports
+	}
+
 	cmdRoot = &cobra.Command{
 		Use:   "ottomap",
 		Short: "ottomap is a tool for managing tribal data",
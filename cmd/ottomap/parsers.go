@@ -0,0 +1,142 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/playbymail/tribal/docx"
+	"github.com/playbymail/tribal/odt"
+	"github.com/playbymail/tribal/parser/backends"
+	"github.com/playbymail/tribal/rtf"
+	"github.com/playbymail/tribal/text"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+	"strings"
+)
+
+var (
+	cmdParsers = &cobra.Command{
+		Use:   "parsers",
+		Short: "tools for working with the parser backends",
+	}
+
+	argsParsersCompare struct {
+		path     string   // path to the report file
+		backends []string // backends to compare, empty for all
+		all      bool     // report values missing from one of the backends
+	}
+
+	cmdParsersCompare = &cobra.Command{
+		Use:   "compare",
+		Short: "run the parser backends on a report and print where they differ",
+		Long: `Run the parser backends on a report and print where they differ.
+The first backend is compared against every other backend.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.SetOutput(io.Discard) // the backends are chatty
+			defer log.SetOutput(os.Stderr)
+
+			var list []backends.ReportParser
+			if len(argsParsersCompare.backends) == 0 {
+				// the section backend is the one we trust, so use it as the baseline
+				b, _ := backends.Lookup("section")
+				list = append(list, b)
+				for _, b := range backends.All() {
+					if b.Name() != "section" {
+						list = append(list, b)
+					}
+				}
+			} else {
+				for _, name := range argsParsersCompare.backends {
+					b, ok := backends.Lookup(name)
+					if !ok {
+						log.SetOutput(os.Stderr)
+						log.Fatalf("parsers: compare: unknown backend %q", name)
+					}
+					list = append(list, b)
+				}
+			}
+			if len(list) < 2 {
+				log.SetOutput(os.Stderr)
+				log.Fatalf("parsers: compare: need at least two backends")
+			}
+
+			if err := runParsersCompare(os.Stdout, argsParsersCompare.path, list, argsParsersCompare.all); err != nil {
+				log.SetOutput(os.Stderr)
+				log.Fatalf("parsers: compare: %v", err)
+			}
+		},
+	}
+)
+
+// runParsersCompare runs the backends on the report and writes the differences to w.
+func runParsersCompare(w io.Writer, path string, list []backends.ReportParser, all bool) error {
+	input, err := readReportText(path)
+	if err != nil {
+		return err
+	}
+
+	results := backends.Run(path, input, list...)
+	for _, r := range results {
+		if r.Error != nil {
+			_, _ = fmt.Fprintf(w, "%-8s  error: %v\n", r.Backend, r.Error)
+			continue
+		}
+		_, _ = fmt.Fprintf(w, "%-8s  %4d units %4d diagnostics\n", r.Backend, len(r.Units), len(r.Diagnostics))
+		for _, d := range r.Diagnostics {
+			_, _ = fmt.Fprintf(w, "          %s\n", d)
+		}
+	}
+
+	baseline := results[0]
+	for _, r := range results[1:] {
+		_, _ = fmt.Fprintf(w, "\n%s vs %s\n", baseline.Backend, r.Backend)
+		if baseline.Error != nil || r.Error != nil {
+			_, _ = fmt.Fprintf(w, "  skipped: backend failed\n")
+			continue
+		}
+		diffs, err := backends.Compare(baseline, r, all)
+		if err != nil {
+			return err
+		} else if len(diffs) == 0 {
+			_, _ = fmt.Fprintf(w, "  no differences\n")
+			continue
+		}
+		for _, d := range diffs {
+			_, _ = fmt.Fprintf(w, "  %s\n", d)
+		}
+	}
+	return nil
+}
+
+// readReportText returns the text of a report.
+// Word, OpenDocument and RTF documents are converted to plain text.
+// Plain text is transcoded to UTF-8.
+func readReportText(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var lines [][]byte
+	switch docx.DetectWordDocType(data) {
+	case docx.Doc:
+		lines, err = docx.ReadDoc(data)
+	case docx.Odt:
+		lines, err = odt.Read(data)
+	case docx.Rtf:
+		lines, err = rtf.Read(data)
+	case docx.Docx:
+		lines, err = docx.Read(data)
+	default:
+		if !strings.HasSuffix(path, ".docx") {
+			return text.Decode(data), nil
+		}
+		lines, err = docx.Read(data)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return bytes.Join(lines, []byte{'\n'}), nil
+}
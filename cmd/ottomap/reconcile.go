@@ -0,0 +1,73 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/playbymail/tribal/tiles"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+)
+
+var (
+	argsReconcile struct {
+		paths []string // paths to the report files
+		json  bool     // write the mismatches as JSON
+	}
+
+	cmdReconcile = &cobra.Command{
+		Use:   "reconcile",
+		Short: "list the suspected errors in the GM's map",
+		Long: `List the borders and passages that the tiles on either side of an edge
+don't agree on.
+
+A river to the north of one hex should be a river to the south of its
+neighbor. When one side reports a feature and the other side was visited
+without reporting it, or the two sides report different features, the
+GM's map database probably has an error. The list is meant to be sent
+to the GM.`,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(argsReconcile.paths) == 0 {
+				return fmt.Errorf("file is required")
+			}
+			return nil
+		},
+		Run: func(cmd *cobra.Command, args []string) {
+			units, err := loadUnits(argsReconcile.paths)
+			if err != nil {
+				log.Fatalf("reconcile: %v", err)
+			}
+			list := tiles.Build(units).Reconcile()
+			if err := writeMismatches(os.Stdout, list, argsReconcile.json); err != nil {
+				log.Fatalf("reconcile: %v", err)
+			}
+		},
+	}
+)
+
+// writeMismatches writes the suspected map errors as text or JSON.
+func writeMismatches(w io.Writer, list []*tiles.Mismatch_t, asJSON bool) error {
+	if asJSON {
+		if list == nil {
+			list = []*tiles.Mismatch_t{}
+		}
+		data, err := json.MarshalIndent(list, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "suspected GM map errors: %d\n", len(list)); err != nil {
+		return err
+	}
+	for _, e := range list {
+		if _, err := fmt.Fprintf(w, "  %s\n", e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
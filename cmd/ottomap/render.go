@@ -0,0 +1,338 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/parser/backends"
+	"github.com/playbymail/tribal/parser/cache"
+	"github.com/playbymail/tribal/render"
+	"github.com/playbymail/tribal/tiles"
+	"github.com/spf13/cobra"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	argsRender struct {
+		paths  []string // paths to the report files
+		center string   // coordinates to center the view on
+		grid   string   // grid, or range of grids, to view
+	}
+
+	cmdRender = &cobra.Command{
+		Use:   "render",
+		Short: "draw the map from turn reports",
+	}
+
+	argsRenderAscii struct {
+		columns     int  // width of the view, in tiles
+		rows        int  // height of the view, in tiles
+		noColor     bool // don't use ANSI colors
+		interactive bool // pan the view with the keyboard
+	}
+
+	cmdRenderAscii = &cobra.Command{
+		Use:   "ascii",
+		Short: "draw the map in the terminal",
+		Long: `Draw the map in the terminal using ANSI colors.
+
+In interactive mode, pan with the arrow keys (or h, j, k, l),
+use H, J, K, L to pan by a full view, and q to quit.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			m, err := loadMap(argsRender.paths)
+			if err != nil {
+				log.Fatalf("render: ascii: %v", err)
+			}
+			v, err := renderView(m, argsRender.center, argsRender.grid, argsRenderAscii.columns, argsRenderAscii.rows)
+			if err != nil {
+				log.Fatalf("render: ascii: %v", err)
+			}
+			opts := render.ASCIIOptions_t{Color: !argsRenderAscii.noColor, Legend: true}
+			if !argsRenderAscii.interactive {
+				if err := render.ASCII(os.Stdout, m, v, opts); err != nil {
+					log.Fatalf("render: ascii: %v", err)
+				}
+				return
+			}
+			if err := runRenderAsciiInteractive(os.Stdin, os.Stdout, m, v, opts); err != nil {
+				log.Fatalf("render: ascii: %v", err)
+			}
+		},
+	}
+
+	argsRenderPng struct {
+		output  string // path to write the image to
+		hexSize int    // distance from the center of a hex to a corner, in pixels
+		columns int    // width of the view, in tiles
+		rows    int    // height of the view, in tiles
+		grid    bool   // draw the hexes that we don't know anything about
+	}
+
+	cmdRenderPng = &cobra.Command{
+		Use:   "png",
+		Short: "draw the map as a PNG image",
+		Long: `Draw the map as a PNG image.
+
+By default, the image covers every tile that we know about.
+Use --grid to crop the image to a grid or range of grids,
+or --center with --columns and --rows to crop around a tile.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if argsRenderPng.output == "" {
+				log.Fatalf("render: png: missing output path\n")
+			}
+			m, err := loadMap(argsRender.paths)
+			if err != nil {
+				log.Fatalf("render: png: %v", err)
+			}
+			v, err := renderView(m, argsRender.center, argsRender.grid, argsRenderPng.columns, argsRenderPng.rows)
+			if err != nil {
+				log.Fatalf("render: png: %v", err)
+			}
+			fd, err := os.Create(argsRenderPng.output)
+			if err != nil {
+				log.Fatalf("render: png: %v", err)
+			}
+			err = render.PNG(fd, m, v, render.PNGOptions_t{HexSize: argsRenderPng.hexSize, Grid: argsRenderPng.grid})
+			if cerr := fd.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				log.Fatalf("render: png: %v", err)
+			}
+			log.Printf("render: png: %s: %s - %s\n", argsRenderPng.output, v.TopLeft, v.BottomRight)
+		},
+	}
+
+	argsRenderGif struct {
+		output  string // path to write the animation to
+		hexSize int    // distance from the center of a hex to a corner, in pixels
+		delay   int    // delay between frames, in hundredths of a second
+		perStep bool   // one frame per step instead of one per turn
+		columns int    // width of the view, in tiles
+		rows    int    // height of the view, in tiles
+		grid    bool   // draw the hexes that we don't know anything about
+	}
+
+	cmdRenderGif = &cobra.Command{
+		Use:   "gif",
+		Short: "replay the exploration of the map as an animated GIF",
+		Long: `Replay the exploration of the map as an animated GIF.
+
+There is one frame per turn, showing the map at the end of the turn.
+Tiles first seen during the turn are outlined in yellow and the steps
+that units took are drawn as tracks. Use --per-step to add a frame
+for every step.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			if argsRenderGif.output == "" {
+				log.Fatalf("render: gif: missing output path\n")
+			}
+			units, err := loadUnits(argsRender.paths)
+			if err != nil {
+				log.Fatalf("render: gif: %v", err)
+			}
+			v, err := renderView(tiles.Build(units), argsRender.center, argsRender.grid, argsRenderGif.columns, argsRenderGif.rows)
+			if err != nil {
+				log.Fatalf("render: gif: %v", err)
+			}
+			fd, err := os.Create(argsRenderGif.output)
+			if err != nil {
+				log.Fatalf("render: gif: %v", err)
+			}
+			err = render.GIF(fd, units, v, render.TimelineOptions_t{
+				PNGOptions_t: render.PNGOptions_t{HexSize: argsRenderGif.hexSize, Grid: argsRenderGif.grid},
+				Delay:        argsRenderGif.delay,
+				PerStep:      argsRenderGif.perStep,
+			})
+			if cerr := fd.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				log.Fatalf("render: gif: %v", err)
+			}
+			log.Printf("render: gif: %s: %d turns\n", argsRenderGif.output, len(tiles.Turns(units)))
+		},
+	}
+)
+
+// loadMap parses the reports and builds the tile model from the units.
+// Borders and passages are mirrored onto the neighboring tiles.
+func loadMap(paths []string) (*tiles.Map_t, error) {
+	units, err := loadUnits(paths)
+	if err != nil {
+		return nil, err
+	}
+	m := tiles.Build(units)
+	m.Reconcile()
+	return m, nil
+}
+
+// loadUnits parses the reports and returns all the units.
+// Reports that haven't changed since they were last parsed are loaded
+// from the cache instead of being parsed again.
+func loadUnits(paths []string) ([]*ast.Unit_t, error) {
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no reports")
+	}
+	parser, _ := backends.Lookup("section")
+	c := openCache()
+	var units []*ast.Unit_t
+	for _, path := range paths {
+		input, err := readReportText(path)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			if list, diagnostics, ok := c.Load(input); ok {
+				for _, d := range diagnostics {
+					log.Printf("%s: %s\n", path, d)
+				}
+				units = append(units, list...)
+				continue
+			}
+		}
+		list, diagnostics, err := parser.Parse(path, input)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		var notes []string
+		for _, d := range diagnostics {
+			log.Printf("%s: %s\n", path, d)
+			notes = append(notes, d.String())
+		}
+		if c != nil {
+			if err := c.Save(input, list, notes); err != nil {
+				log.Printf("%s: cache: %v\n", path, err)
+			}
+		}
+		units = append(units, list...)
+	}
+	return units, nil
+}
+
+// openCache returns the cache of parsed reports, or nil if it is disabled
+// or can't be opened.
+func openCache() *cache.Cache_t {
+	if argsRoot.noCache {
+		return nil
+	}
+	path := argsRoot.cacheDir
+	if path == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return nil
+		}
+		path = filepath.Join(dir, "ottomap", "reports")
+	}
+	c, err := cache.Open(path)
+	if err != nil {
+		log.Printf("cache: %v\n", err)
+		return nil
+	}
+	return c
+}
+
+// renderView returns the view for the command line options.
+// A grid (or range of grids, like "KP-LQ") takes precedence over the center.
+// With neither, the view is centered on the first unit's location,
+// or covers the whole map if the size of the view isn't set.
+func renderView(m *tiles.Map_t, center, grid string, columns, rows int) (render.View_t, error) {
+	if grid != "" {
+		from, to, ok := strings.Cut(grid, "-")
+		if !ok {
+			to = from
+		}
+		return render.ViewOfGrids(from, to)
+	}
+	if center != "" {
+		c, err := ast.TextToCoordinates([]byte(strings.ToLower(center)))
+		if err != nil {
+			return render.View_t{}, fmt.Errorf("center %q: %w", center, err)
+		}
+		p, ok := tiles.ToPoint(c)
+		if !ok {
+			return render.View_t{}, fmt.Errorf("center %q: %w", center, ast.ErrInvalidCoordinates)
+		}
+		if columns < 1 || rows < 1 {
+			columns, rows = 16, 10
+		}
+		return render.ViewAround(p, columns, rows), nil
+	}
+	for _, t := range m.Sorted() {
+		if columns < 1 || rows < 1 {
+			break
+		} else if len(t.Units) != 0 {
+			return render.ViewAround(t.Point, columns, rows), nil
+		}
+	}
+	if v, ok := render.ViewOfMap(m, 1); ok {
+		return v, nil
+	}
+	return render.View_t{}, fmt.Errorf("map is empty")
+}
+
+// runRenderAsciiInteractive redraws the map every time the user pans the view.
+// We use stty to read single key presses. If that fails, the user has to press
+// enter after each key.
+func runRenderAsciiInteractive(r *os.File, w io.Writer, m *tiles.Map_t, v render.View_t, opts render.ASCIIOptions_t) error {
+	if err := stty(r, "-icanon", "-echo", "min", "1"); err == nil {
+		defer func() {
+			_ = stty(r, "icanon", "echo")
+		}()
+	}
+
+	in := bufio.NewReader(r)
+	for {
+		_, _ = fmt.Fprint(w, "\x1b[H\x1b[2J") // move home and clear screen
+		if err := render.ASCII(w, m, v, opts); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(w, "\n%s - %s  (arrows or hjkl to pan, HJKL to page, q to quit)\n", v.TopLeft, v.BottomRight)
+
+		ch, err := in.ReadByte()
+		if err != nil {
+			return nil
+		}
+		if ch == 0x1b { // arrow keys are ESC [ A through ESC [ D
+			if next, err := in.ReadByte(); err != nil || next != '[' {
+				continue
+			} else if ch, err = in.ReadByte(); err != nil {
+				return nil
+			}
+			ch = map[byte]byte{'A': 'k', 'B': 'j', 'C': 'l', 'D': 'h'}[ch]
+		}
+		switch ch {
+		case 'h':
+			v = v.Pan(-2, 0) // keep the odd/even column offset the same
+		case 'l':
+			v = v.Pan(2, 0)
+		case 'k':
+			v = v.Pan(0, -1)
+		case 'j':
+			v = v.Pan(0, 1)
+		case 'H':
+			v = v.Pan(-v.Columns()&^1, 0)
+		case 'L':
+			v = v.Pan(v.Columns()&^1, 0)
+		case 'K':
+			v = v.Pan(0, -v.Rows())
+		case 'J':
+			v = v.Pan(0, v.Rows())
+		case 'q', 'Q', 0x03, 0x04:
+			return nil
+		}
+	}
+}
+
+// stty changes the settings of the terminal attached to the file.
+func stty(tty *os.File, args ...string) error {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	return cmd.Run()
+}
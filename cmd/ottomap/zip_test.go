@@ -0,0 +1,146 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"github.com/playbymail/tribal/store"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestStore returns a store backed by a temporary database that is
+// created from the schema. The database has a single clan, 0987.
+func newTestStore(t *testing.T) *store.Store {
+	t.Helper()
+	schema, err := os.ReadFile(filepath.Join("..", "..", "store", "sqlc", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "tribal.sqlite")
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec(string(schema))
+	_ = db.Close()
+	if err != nil {
+		t.Fatalf("schema: %v", err)
+	}
+	s, err := store.Open(path, context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = s.Close()
+	})
+	if _, err := s.CreateClan(987); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// newTestZip returns an archive with the given entries, in order.
+func newTestZip(t *testing.T, entries ...string) []byte {
+	t.Helper()
+	if len(entries)%2 != 0 {
+		t.Fatal("entries must be name, data pairs")
+	}
+	bb := &bytes.Buffer{}
+	zw := zip.NewWriter(bb)
+	for i := 0; i < len(entries); i += 2 {
+		w, err := zw.Create(entries[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(entries[i+1])); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return bb.Bytes()
+}
+
+func TestRunImportZip(t *testing.T) {
+	turn5, err := os.ReadFile(filepath.Join("..", "..", "section", "testdata", "scouts.report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	turn4 := bytes.Replace(turn5, []byte("900-05 (#5)"), []byte("900-04 (#4)"), 1)
+	if bytes.Equal(turn4, turn5) {
+		t.Fatal("turn 4 report: missing turn line")
+	}
+
+	defer func(n int64) { maxZipEntrySize = n }(maxZipEntrySize)
+	maxZipEntrySize = int64(len(turn5)) + 64
+
+	t.Run("order", func(t *testing.T) {
+		s := newTestStore(t)
+		data := newTestZip(t,
+			"0900-05.0987.report.txt", string(turn5),
+			"notes/readme.txt", "not a report",
+			"0900-04.0987.report.txt", string(turn4),
+			"0900-06.0987.report.txt", string(turn5)+string(bytes.Repeat([]byte{' '}, 128)),
+		)
+		imported, skipped, err := runImportZip(s, "reports.zip", data, 0, false)
+		if err != nil {
+			t.Fatal(err)
+		} else if imported != 2 {
+			t.Errorf("imported: want 2, got %d", imported)
+		} else if skipped != 2 {
+			t.Errorf("skipped: want 2, got %d", skipped)
+		}
+
+		r4, err := s.GetReportByHash(store.Hash(turn4))
+		if err != nil || r4 == nil {
+			t.Fatalf("turn 4: want report, got %v, %v", r4, err)
+		}
+		r5, err := s.GetReportByHash(store.Hash(turn5))
+		if err != nil || r5 == nil {
+			t.Fatalf("turn 5: want report, got %v, %v", r5, err)
+		}
+		if r4.Id >= r5.Id {
+			t.Errorf("order: want turn 4 before turn 5, got ids %d and %d", r4.Id, r5.Id)
+		}
+		if want := "reports.zip/0900-04.0987.report.txt"; r4.Name != want {
+			t.Errorf("name: want %q, got %q", want, r4.Name)
+		}
+	})
+
+	t.Run("size", func(t *testing.T) {
+		// the header is honest here, so shrink the limit below the entry size
+		defer func(n int64) { maxZipEntrySize = n }(maxZipEntrySize)
+		maxZipEntrySize = int64(len(turn5)) - 1
+		s := newTestStore(t)
+		data := newTestZip(t, "0900-05.0987.report.txt", string(turn5))
+		imported, skipped, err := runImportZip(s, "reports.zip", data, 0, false)
+		if err != nil {
+			t.Fatal(err)
+		} else if imported != 0 || skipped != 1 {
+			t.Errorf("imported, skipped: want 0, 1, got %d, %d", imported, skipped)
+		}
+	})
+
+	t.Run("clan", func(t *testing.T) {
+		s := newTestStore(t)
+		// the entry name says clan 0123, but the moves must be saved for clan 0987
+		data := newTestZip(t, "0900-05.0123.report.txt", string(turn5))
+		imported, skipped, err := runImportZip(s, "reports.zip", data, 987, false)
+		if err != nil {
+			t.Fatal(err)
+		} else if imported != 1 || skipped != 0 {
+			t.Errorf("imported, skipped: want 1, 0, got %d, %d", imported, skipped)
+		}
+		if moves, err := s.GetUnitMoves(987, 5, "0987s1"); err != nil {
+			t.Fatal(err)
+		} else if len(moves) == 0 {
+			t.Errorf("clan 0987: want moves, got none")
+		}
+	})
+}
@@ -33,7 +33,7 @@ func run(path string) error {
 
 	// split the input into sections
 	now = time.Now()
-	sections := section.Split(data)
+	sections := section.New(section.Config_t{}).Split(data)
 	log.Printf("sectioned                  %8d lines into %8d sections in %v", 1, len(sections), time.Since(now))
 
 	now = time.Now()
@@ -113,7 +113,7 @@ func run(path string) error {
 
 	// split the lines into sections
 	now = time.Now()
-	sections := section.Split(lines)
+	sections := section.New(section.Config_t{}).Split(lines)
 	log.Printf("sectioned                  %8d lines into %8d sections in %v", len(lines), len(sections), time.Since(now))
 
 	now = time.Now()
@@ -51,7 +51,7 @@ func loadDocxFiles(name string, printLines bool) error {
 		}
 	}
 	// parse the report text into sections
-	sections := section.Split(data)
+	sections := section.New(section.Config_t{}).Split(data)
 	log.Printf("%s: %4d sections in %v", name, len(sections), time.Since(started))
 	for n, ss := range sections {
 		log.Printf("docx: %4d: %s", n, ss.Header)
@@ -88,7 +88,7 @@ func loadTextFiles(name string, printLines bool) error {
 		}
 	}
 	// parse the report text into sections
-	sections := section.Split(data)
+	sections := section.New(section.Config_t{}).Split(data)
 	log.Printf("%s: %4d sections in %v", name, len(sections), time.Since(started))
 	for n, ss := range sections {
 		log.Printf("text: %4d: %s", n, ss.Header)
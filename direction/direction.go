@@ -60,10 +60,12 @@ func (d *Direction_e) UnmarshalJSON(data []byte) error {
 
 // UnmarshalText implements the encoding.TextUnmarshaler interface.
 // This is needed for unmarshalling the enum as map keys.
-//
-// Note that this is never called; it just changes the code path in UnmarshalJSON.
-func (d Direction_e) UnmarshalText(text []byte) error {
-	panic("!")
+func (d *Direction_e) UnmarshalText(text []byte) error {
+	var ok bool
+	if *d, ok = StringToEnum[string(text)]; !ok {
+		return fmt.Errorf("invalid Direction %q", text)
+	}
+	return nil
 }
 
 // String implements the fmt.Stringer interface.
@@ -130,6 +132,17 @@ var EvenColumnVectors = map[Direction_e][2]int{
 	NorthWest: [2]int{-1, +0}, // ## 1206 -> ## 1106
 }
 
+// Opposite is the direction that points back across the edge.
+var Opposite = map[Direction_e]Direction_e{
+	None:      None,
+	North:     South,
+	NorthEast: SouthWest,
+	SouthEast: NorthWest,
+	South:     North,
+	SouthWest: NorthEast,
+	NorthWest: SouthEast,
+}
+
 // Add moves in the given direction and returns the new row and column.
 // It always moves a single hex and allows for moving between grids and wrapping around the big map.
 func Add(row, col int, d Direction_e) (int, int) {
@@ -0,0 +1,415 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package docx
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"github.com/playbymail/tribal/text"
+	"os"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Word 97-2003 documents are OLE2 compound files (MS-CFB) that contain a
+// WordDocument stream and a table stream (MS-DOC). The text of the document
+// is stored in pieces, which are listed in the piece table in the table stream.
+// We only extract the text of the main document; headers, footnotes and the
+// like are ignored.
+
+// ReadDocFile loads a Word 97-2003 document from a file and returns the text
+// as a slice of byte slices. Each slice of bytes is a paragraph in the document.
+func ReadDocFile(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ReadDoc(data)
+}
+
+// ReadDoc reads a Word 97-2003 document from a byte slice and returns the
+// contents in the same form as Read: one slice of bytes per paragraph.
+func ReadDoc(data []byte) ([][]byte, error) {
+	if DetectWordDocType(data) != Doc {
+		return nil, ErrNotADocument
+	}
+	cf, err := openCompoundFile(data)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+	wordDocument, err := cf.stream("WordDocument")
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+	fib, err := readFib(wordDocument)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	} else if fib.encrypted {
+		return nil, ErrEncryptedDocument
+	}
+	table, err := cf.stream(fib.tableStream)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+	text, err := readPieces(wordDocument, table, fib)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+	return docParagraphs(text), nil
+}
+
+// compound file sector markers
+const (
+	cfbMaxRegSect  = 0xFFFFFFFA
+	cfbEndOfChain  = 0xFFFFFFFE
+	cfbHeaderSize  = 512
+	cfbDirEntry    = 128
+	cfbStreamEntry = 2
+	cfbRootEntry   = 5
+)
+
+// compoundFile_t is an OLE2 compound file.
+type compoundFile_t struct {
+	data           []byte
+	sectorSize     int
+	miniSectorSize int
+	miniCutoff     uint64
+	fat            []uint32
+	miniFat        []uint32
+	miniStream     []byte
+	entries        []*cfbEntry_t
+}
+
+// cfbEntry_t is a single entry in the compound file directory.
+type cfbEntry_t struct {
+	name  string
+	kind  byte
+	start uint32
+	size  uint64
+}
+
+func openCompoundFile(data []byte) (*compoundFile_t, error) {
+	if len(data) < cfbHeaderSize {
+		return nil, errors.New("header too short")
+	} else if binary.LittleEndian.Uint16(data[0x1C:]) != 0xFFFE {
+		return nil, errors.New("invalid byte order")
+	}
+	cf := &compoundFile_t{
+		data:           data,
+		sectorSize:     1 << binary.LittleEndian.Uint16(data[0x1E:]),
+		miniSectorSize: 1 << binary.LittleEndian.Uint16(data[0x20:]),
+		miniCutoff:     uint64(binary.LittleEndian.Uint32(data[0x38:])),
+	}
+	if cf.sectorSize != 512 && cf.sectorSize != 4096 {
+		return nil, errors.New("invalid sector size")
+	} else if cf.miniSectorSize != 64 {
+		return nil, errors.New("invalid mini sector size")
+	}
+	numFatSectors := int(binary.LittleEndian.Uint32(data[0x2C:]))
+	firstDirSector := binary.LittleEndian.Uint32(data[0x30:])
+	firstMiniFatSector := binary.LittleEndian.Uint32(data[0x3C:])
+	firstDifatSector := binary.LittleEndian.Uint32(data[0x44:])
+
+	// the first 109 FAT sectors are listed in the header, the rest in a chain of DIFAT sectors.
+	var fatSectors []uint32
+	for i := 0; i < 109 && len(fatSectors) < numFatSectors; i++ {
+		fatSectors = append(fatSectors, binary.LittleEndian.Uint32(data[0x4C+4*i:]))
+	}
+	perSector := cf.sectorSize / 4
+	for sect, seen := firstDifatSector, 0; sect <= cfbMaxRegSect && len(fatSectors) < numFatSectors; seen++ {
+		buf, err := cf.sector(sect)
+		if err != nil {
+			return nil, err
+		} else if seen > len(data)/cf.sectorSize {
+			return nil, errors.New("DIFAT chain loops")
+		} else if len(buf) < cf.sectorSize {
+			// the link to the next DIFAT sector is in the last four bytes
+			return nil, errors.New("truncated DIFAT sector")
+		}
+		for i := 0; i < perSector-1 && len(fatSectors) < numFatSectors; i++ {
+			fatSectors = append(fatSectors, binary.LittleEndian.Uint32(buf[4*i:]))
+		}
+		sect = binary.LittleEndian.Uint32(buf[4*(perSector-1):])
+	}
+	for _, sect := range fatSectors {
+		buf, err := cf.sector(sect)
+		if err != nil {
+			return nil, err
+		}
+		// the last sector in the file may be short
+		for i := 0; i < perSector && 4*i+4 <= len(buf); i++ {
+			cf.fat = append(cf.fat, binary.LittleEndian.Uint32(buf[4*i:]))
+		}
+	}
+
+	dir, err := cf.chain(firstDirSector, cf.fat, cf.sector)
+	if err != nil {
+		return nil, err
+	}
+	for off := 0; off+cfbDirEntry <= len(dir); off += cfbDirEntry {
+		e := dir[off : off+cfbDirEntry]
+		nameLen := int(binary.LittleEndian.Uint16(e[0x40:]))
+		if nameLen > 64 {
+			nameLen = 64
+		}
+		var name []uint16
+		for i := 0; i+1 < nameLen; i += 2 {
+			if ch := binary.LittleEndian.Uint16(e[i:]); ch != 0 {
+				name = append(name, ch)
+			}
+		}
+		entry := &cfbEntry_t{
+			name:  string(utf16.Decode(name)),
+			kind:  e[0x42],
+			start: binary.LittleEndian.Uint32(e[0x74:]),
+			size:  binary.LittleEndian.Uint64(e[0x78:]),
+		}
+		if cf.sectorSize == 512 {
+			// version 3 files may have junk in the high bytes of the size
+			entry.size &= 0xFFFFFFFF
+		}
+		cf.entries = append(cf.entries, entry)
+	}
+	if len(cf.entries) == 0 || cf.entries[0].kind != cfbRootEntry {
+		return nil, errors.New("missing root entry")
+	}
+
+	// small streams are stored in the mini stream, which is owned by the root entry.
+	if firstMiniFatSector <= cfbMaxRegSect {
+		buf, err := cf.chain(firstMiniFatSector, cf.fat, cf.sector)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i+4 <= len(buf); i += 4 {
+			cf.miniFat = append(cf.miniFat, binary.LittleEndian.Uint32(buf[i:]))
+		}
+		root := cf.entries[0]
+		if cf.miniStream, err = cf.chain(root.start, cf.fat, cf.sector); err != nil {
+			return nil, err
+		} else if uint64(len(cf.miniStream)) > root.size {
+			cf.miniStream = cf.miniStream[:root.size]
+		}
+	}
+	return cf, nil
+}
+
+// stream returns the contents of the named stream.
+func (cf *compoundFile_t) stream(name string) ([]byte, error) {
+	for _, e := range cf.entries {
+		if e.kind != cfbStreamEntry || e.name != name {
+			continue
+		}
+		var buf []byte
+		var err error
+		if e.size < cf.miniCutoff {
+			buf, err = cf.chain(e.start, cf.miniFat, cf.miniSector)
+		} else {
+			buf, err = cf.chain(e.start, cf.fat, cf.sector)
+		}
+		if err != nil {
+			return nil, err
+		} else if uint64(len(buf)) < e.size {
+			return nil, errors.New(name + ": stream too short")
+		}
+		return buf[:e.size], nil
+	}
+	return nil, errors.New(name + ": stream not found")
+}
+
+// chain returns the contents of the sectors in the chain that starts at the sector.
+func (cf *compoundFile_t) chain(start uint32, fat []uint32, sector func(uint32) ([]byte, error)) ([]byte, error) {
+	var buf []byte
+	for sect, n := start, 0; sect != cfbEndOfChain; n++ {
+		if sect > cfbMaxRegSect || int(sect) >= len(fat) {
+			return nil, errors.New("invalid sector in chain")
+		} else if n > len(fat) {
+			return nil, errors.New("sector chain loops")
+		}
+		data, err := sector(sect)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(buf, data...)
+		sect = fat[sect]
+	}
+	return buf, nil
+}
+
+func (cf *compoundFile_t) sector(sect uint32) ([]byte, error) {
+	off := (int(sect) + 1) * cf.sectorSize
+	if sect > cfbMaxRegSect || off >= len(cf.data) {
+		return nil, errors.New("sector out of range")
+	} else if off+cf.sectorSize > len(cf.data) {
+		// some writers don't pad the last sector
+		return cf.data[off:], nil
+	}
+	return cf.data[off : off+cf.sectorSize], nil
+}
+
+func (cf *compoundFile_t) miniSector(sect uint32) ([]byte, error) {
+	off := int(sect) * cf.miniSectorSize
+	if off+cf.miniSectorSize > len(cf.miniStream) {
+		return nil, errors.New("mini sector out of range")
+	}
+	return cf.miniStream[off : off+cf.miniSectorSize], nil
+}
+
+// fib_t holds the parts of the File Information Block that we need.
+type fib_t struct {
+	encrypted   bool
+	tableStream string // "0Table" or "1Table"
+	ccpText     int    // number of characters in the main document
+	fcClx       int    // offset of the piece table in the table stream
+	lcbClx      int    // length of the piece table
+}
+
+func readFib(wd []byte) (*fib_t, error) {
+	if len(wd) < 0x22 || binary.LittleEndian.Uint16(wd) != 0xA5EC {
+		return nil, errors.New("invalid FIB")
+	}
+	flags := binary.LittleEndian.Uint16(wd[0x0A:])
+	fib := &fib_t{
+		encrypted:   flags&0x0100 != 0,
+		tableStream: "0Table",
+	}
+	if flags&0x0200 != 0 {
+		fib.tableStream = "1Table"
+	}
+	// FibBase is followed by three variable length arrays, each starting with a count.
+	off := 0x20
+	csw := int(binary.LittleEndian.Uint16(wd[off:]))
+	off += 2 + 2*csw
+	if off+2 > len(wd) {
+		return nil, errors.New("FIB too short")
+	}
+	cslw := int(binary.LittleEndian.Uint16(wd[off:]))
+	rgLw := off + 2
+	off = rgLw + 4*cslw
+	if cslw < 4 || off+2 > len(wd) {
+		return nil, errors.New("FIB too short")
+	}
+	fib.ccpText = int(binary.LittleEndian.Uint32(wd[rgLw+4*3:]))
+	cbRgFcLcb := int(binary.LittleEndian.Uint16(wd[off:]))
+	rgFcLcb := off + 2
+	const clx = 33 // index of fcClx in FibRgFcLcb97
+	if cbRgFcLcb <= clx || rgFcLcb+8*(clx+1) > len(wd) {
+		return nil, errors.New("FIB too short")
+	}
+	fib.fcClx = int(binary.LittleEndian.Uint32(wd[rgFcLcb+8*clx:]))
+	fib.lcbClx = int(binary.LittleEndian.Uint32(wd[rgFcLcb+8*clx+4:]))
+	return fib, nil
+}
+
+// readPieces returns the text of the main document, decoded from the pieces
+// listed in the piece table.
+func readPieces(wd, table []byte, fib *fib_t) ([]rune, error) {
+	if fib.lcbClx == 0 || fib.fcClx+fib.lcbClx > len(table) {
+		return nil, errors.New("missing piece table")
+	}
+	clx := table[fib.fcClx : fib.fcClx+fib.lcbClx]
+	// skip the property modifiers (Prc) that come before the piece table (Pcdt)
+	for len(clx) != 0 && clx[0] == 0x01 {
+		if len(clx) < 3 {
+			return nil, errors.New("invalid Prc")
+		}
+		cb := int(binary.LittleEndian.Uint16(clx[1:]))
+		if 3+cb > len(clx) {
+			return nil, errors.New("invalid Prc")
+		}
+		clx = clx[3+cb:]
+	}
+	if len(clx) < 5 || clx[0] != 0x02 {
+		return nil, errors.New("missing Pcdt")
+	}
+	lcb := int(binary.LittleEndian.Uint32(clx[1:]))
+	plc := clx[5:]
+	if lcb > len(plc) || lcb < 4 || (lcb-4)%12 != 0 {
+		return nil, errors.New("invalid PlcPcd")
+	}
+	n := (lcb - 4) / 12
+	cp := func(i int) int { return int(binary.LittleEndian.Uint32(plc[4*i:])) }
+	pcds := plc[4*(n+1):]
+
+	var chars []rune
+	for i := 0; i < n && len(chars) < fib.ccpText; i++ {
+		count := cp(i+1) - cp(i)
+		if count < 0 {
+			return nil, errors.New("invalid piece")
+		} else if remaining := fib.ccpText - len(chars); count > remaining {
+			count = remaining
+		}
+		fc := binary.LittleEndian.Uint32(pcds[8*i+2:])
+		if fc&0x40000000 != 0 {
+			// compressed pieces are 8-bit Windows-1252
+			off := int(fc&0x3FFFFFFF) / 2
+			if off+count > len(wd) {
+				return nil, errors.New("piece out of range")
+			}
+			for _, b := range wd[off : off+count] {
+				chars = append(chars, text.DecodeWindows1252(b))
+			}
+		} else {
+			off := int(fc & 0x3FFFFFFF)
+			if off+2*count > len(wd) {
+				return nil, errors.New("piece out of range")
+			}
+			units := make([]uint16, count)
+			for j := range units {
+				units[j] = binary.LittleEndian.Uint16(wd[off+2*j:])
+			}
+			chars = append(chars, utf16.Decode(units)...)
+		}
+	}
+	return chars, nil
+}
+
+// docParagraphs splits the text into paragraphs and drops the special
+// characters that Word uses for fields, pictures and the like.
+// Field codes are dropped, but the field results are kept.
+func docParagraphs(text []rune) [][]byte {
+	var paragraphs [][]byte
+	var para []byte
+	// fields may be nested. each entry is true while we're in the field code.
+	var fields []bool
+	for _, r := range text {
+		switch r {
+		case 0x13: // field begin
+			fields = append(fields, true)
+			continue
+		case 0x14: // field separator
+			if len(fields) != 0 {
+				fields[len(fields)-1] = false
+			}
+			continue
+		case 0x15: // field end
+			if len(fields) != 0 {
+				fields = fields[:len(fields)-1]
+			}
+			continue
+		}
+		if len(fields) != 0 && fields[len(fields)-1] {
+			continue
+		}
+		switch {
+		case r == '\r' || r == 0x07 || r == 0x0B || r == 0x0C:
+			// paragraph, cell, line and page breaks
+			paragraphs = append(paragraphs, bytes.Clone(para))
+			para = para[:0]
+		case r == 0x1E: // non-breaking hyphen
+			para = append(para, '-')
+		case r == 0xA0: // non-breaking space
+			para = append(para, ' ')
+		case r == '\t':
+			para = append(para, '\t')
+		case r < 0x20 || r == 0x1F:
+			// objects, footnote marks and optional hyphens
+		default:
+			para = utf8.AppendRune(para, r)
+		}
+	}
+	if len(para) != 0 {
+		paragraphs = append(paragraphs, bytes.Clone(para))
+	}
+	return paragraphs
+}
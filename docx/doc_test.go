@@ -0,0 +1,248 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package docx_test
+
+import (
+	"encoding/binary"
+	"errors"
+	"github.com/playbymail/tribal/docx"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestReadDoc(t *testing.T) {
+	want := []string{
+		"Tribe 0987, , Current Hex = KP 0608",
+		"Café “Los Angeles”",
+		"Current Turn 900-05 (#5), Summer, FINE",
+	}
+	got, err := docx.ReadDoc(testDocument(t))
+	if err != nil {
+		t.Fatalf("ReadDoc: %v", err)
+	} else if len(got) != len(want) {
+		t.Fatalf("ReadDoc: want %d paragraphs, got %d: %q", len(want), len(got), got)
+	}
+	for i := range want {
+		if string(got[i]) != want[i] {
+			t.Errorf("paragraph %d: want %q, got %q", i+1, want[i], got[i])
+		}
+	}
+
+	if _, err := docx.ReadDoc([]byte("plain text")); !errors.Is(err, docx.ErrNotADocument) {
+		t.Errorf("plain text: want %v, got %v", docx.ErrNotADocument, err)
+	}
+}
+
+// TestReadDocTruncated verifies that truncated files are errors, not panics.
+func TestReadDocTruncated(t *testing.T) {
+	// a header that lists one FAT sector, followed by part of that sector
+	data := truncatedHeader()
+	if _, err := docx.ReadDoc(data); err == nil {
+		t.Errorf("truncated FAT: want error, got nil")
+	}
+
+	// the same, but with the FAT sector listed in a DIFAT sector
+	data = truncatedHeader()
+	binary.LittleEndian.PutUint32(data[0x4C:], 0xFFFFFFFF)
+	binary.LittleEndian.PutUint32(data[0x44:], 0) // first DIFAT sector
+	binary.LittleEndian.PutUint32(data[0x48:], 1) // DIFAT sectors
+	if _, err := docx.ReadDoc(data); err == nil {
+		t.Errorf("truncated DIFAT: want error, got nil")
+	}
+
+	doc := testDocument(t)
+	for n := 0; n < len(doc); n += 7 {
+		_, _ = docx.ReadDoc(doc[:n])
+	}
+}
+
+func FuzzReadDoc(f *testing.F) {
+	f.Add(testDocument(f))
+	f.Add(truncatedHeader())
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = docx.ReadDoc(data)
+	})
+}
+
+// truncatedHeader returns a compound file header with one FAT sector at
+// sector 0, followed by only 10 bytes of that sector.
+func truncatedHeader() []byte {
+	header := make([]byte, 512)
+	copy(header, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1})
+	binary.LittleEndian.PutUint16(header[0x1A:], 3)
+	binary.LittleEndian.PutUint16(header[0x1C:], 0xFFFE)
+	binary.LittleEndian.PutUint16(header[0x1E:], 9)
+	binary.LittleEndian.PutUint16(header[0x20:], 6)
+	binary.LittleEndian.PutUint32(header[0x2C:], 1) // FAT sectors
+	binary.LittleEndian.PutUint32(header[0x44:], 0xFFFFFFFE)
+	for i := 0; i < 109; i++ {
+		binary.LittleEndian.PutUint32(header[0x4C+4*i:], 0xFFFFFFFF)
+	}
+	binary.LittleEndian.PutUint32(header[0x4C:], 0)
+	return append(header, make([]byte, 10)...)
+}
+
+// testDocument returns a Word 97-2003 document with two pieces of text.
+// The first piece is 8-bit Windows-1252, the second is UTF-16 with a field.
+func testDocument(tb testing.TB) []byte {
+	tb.Helper()
+	pieces := []struct {
+		text       []byte
+		compressed bool
+	}{
+		{[]byte("Tribe 0987, , Current Hex = KP 0608\rCaf\xe9 \x93Los Angeles\x94\r"), true},
+		{utf16le("Current Turn 900-05 (#5), \x13 HYPERLINK \"x\" \x14Summer\x15, FINE\r"), false},
+	}
+
+	// the WordDocument stream has the FIB and the text of the pieces.
+	// it is large enough to be stored in regular sectors.
+	wd := make([]byte, 5000)
+	binary.LittleEndian.PutUint16(wd[0x00:], 0xA5EC)
+	binary.LittleEndian.PutUint16(wd[0x02:], 0x00C1)
+	binary.LittleEndian.PutUint16(wd[0x0A:], 0x0200) // fWhichTblStm, so the table is 1Table
+	off := 0x20
+	binary.LittleEndian.PutUint16(wd[off:], 14) // csw
+	off += 2 + 2*14
+	binary.LittleEndian.PutUint16(wd[off:], 22) // cslw
+	rgLw := off + 2
+	off = rgLw + 4*22
+	binary.LittleEndian.PutUint16(wd[off:], 93) // cbRgFcLcb
+	rgFcLcb := off + 2
+
+	// the table stream has the piece table. it is small, so it goes in the mini stream.
+	var cps []uint32
+	var pcds []byte
+	cp, fc := uint32(0), uint32(0x800)
+	for _, p := range pieces {
+		copy(wd[fc:], p.text)
+		pcd := make([]byte, 8)
+		n := uint32(len(p.text))
+		if p.compressed {
+			binary.LittleEndian.PutUint32(pcd[2:], fc*2|0x40000000)
+		} else {
+			binary.LittleEndian.PutUint32(pcd[2:], fc)
+			n /= 2
+		}
+		cps, pcds = append(cps, cp), append(pcds, pcd...)
+		cp, fc = cp+n, fc+0x100
+	}
+	cps = append(cps, cp)
+	binary.LittleEndian.PutUint32(wd[rgLw+4*3:], cp) // ccpText
+	table := []byte{0x01, 0x02, 0x00, 0xAA, 0xBB}    // a Prc to skip
+	plc := make([]byte, 4*len(cps))
+	for i, cp := range cps {
+		binary.LittleEndian.PutUint32(plc[4*i:], cp)
+	}
+	plc = append(plc, pcds...)
+	table = append(table, 0x02, 0, 0, 0, 0)
+	binary.LittleEndian.PutUint32(table[len(table)-4:], uint32(len(plc)))
+	table = append(table, plc...)
+	binary.LittleEndian.PutUint32(wd[rgFcLcb+8*33:], 0)                    // fcClx
+	binary.LittleEndian.PutUint32(wd[rgFcLcb+8*33+4:], uint32(len(table))) // lcbClx
+
+	return compoundFile(tb, wd, table)
+}
+
+// compoundFile returns a version 3 compound file with a WordDocument stream
+// in regular sectors and a 1Table stream in the mini stream.
+//
+// sector 0 is the FAT, 1 is the directory, 2 is the mini FAT, then come the
+// mini stream and the WordDocument stream.
+func compoundFile(tb testing.TB, wd, table []byte) []byte {
+	tb.Helper()
+	const sectorSize, miniSectorSize = 512, 64
+	const endOfChain, fatSect, freeSect = 0xFFFFFFFE, 0xFFFFFFFD, 0xFFFFFFFF
+	pad := func(b []byte, size int) []byte {
+		for len(b)%size != 0 {
+			b = append(b, 0)
+		}
+		return b
+	}
+	mini := pad(append([]byte{}, table...), sectorSize)
+	wdPadded := pad(append([]byte{}, wd...), sectorSize)
+	miniStart, wdStart := 3, 3+len(mini)/sectorSize
+	numSectors := wdStart + len(wdPadded)/sectorSize
+	if numSectors > sectorSize/4 {
+		tb.Fatal("compound file: too many sectors for one FAT sector")
+	}
+
+	fat := make([]uint32, sectorSize/4)
+	for i := range fat {
+		fat[i] = freeSect
+	}
+	fat[0], fat[1], fat[2] = fatSect, endOfChain, endOfChain
+	chain := func(start, count int) {
+		for i := start; i < start+count-1; i++ {
+			fat[i] = uint32(i + 1)
+		}
+		fat[start+count-1] = endOfChain
+	}
+	chain(miniStart, len(mini)/sectorSize)
+	chain(wdStart, len(wdPadded)/sectorSize)
+
+	miniFat := make([]uint32, sectorSize/4)
+	for i := range miniFat {
+		miniFat[i] = freeSect
+	}
+	n := (len(table) + miniSectorSize - 1) / miniSectorSize
+	for i := 0; i < n-1; i++ {
+		miniFat[i] = uint32(i + 1)
+	}
+	miniFat[n-1] = endOfChain
+
+	dir := make([]byte, sectorSize)
+	entry := func(i int, name string, kind byte, start, size int) {
+		e := dir[128*i : 128*(i+1)]
+		units := utf16.Encode([]rune(name))
+		for j, u := range units {
+			binary.LittleEndian.PutUint16(e[2*j:], u)
+		}
+		binary.LittleEndian.PutUint16(e[0x40:], uint16(2*(len(units)+1)))
+		e[0x42] = kind
+		binary.LittleEndian.PutUint32(e[0x44:], freeSect)
+		binary.LittleEndian.PutUint32(e[0x48:], freeSect)
+		binary.LittleEndian.PutUint32(e[0x4C:], freeSect)
+		binary.LittleEndian.PutUint32(e[0x74:], uint32(start))
+		binary.LittleEndian.PutUint64(e[0x78:], uint64(size))
+	}
+	entry(0, "Root Entry", 5, miniStart, len(mini))
+	entry(1, "WordDocument", 2, wdStart, len(wd))
+	entry(2, "1Table", 2, 0, len(table))
+
+	header := make([]byte, sectorSize)
+	copy(header, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1})
+	binary.LittleEndian.PutUint16(header[0x18:], 0x3E)
+	binary.LittleEndian.PutUint16(header[0x1A:], 3)
+	binary.LittleEndian.PutUint16(header[0x1C:], 0xFFFE)
+	binary.LittleEndian.PutUint16(header[0x1E:], 9)
+	binary.LittleEndian.PutUint16(header[0x20:], 6)
+	binary.LittleEndian.PutUint32(header[0x2C:], 1) // FAT sectors
+	binary.LittleEndian.PutUint32(header[0x30:], 1) // first directory sector
+	binary.LittleEndian.PutUint32(header[0x38:], 4096)
+	binary.LittleEndian.PutUint32(header[0x3C:], 2) // first mini FAT sector
+	binary.LittleEndian.PutUint32(header[0x40:], 1) // mini FAT sectors
+	binary.LittleEndian.PutUint32(header[0x44:], endOfChain)
+	for i := 0; i < 109; i++ {
+		binary.LittleEndian.PutUint32(header[0x4C+4*i:], freeSect)
+	}
+	binary.LittleEndian.PutUint32(header[0x4C:], 0)
+
+	data := append([]byte{}, header...)
+	for _, v := range fat {
+		data = binary.LittleEndian.AppendUint32(data, v)
+	}
+	data = append(data, dir...)
+	for _, v := range miniFat {
+		data = binary.LittleEndian.AppendUint32(data, v)
+	}
+	data = append(data, mini...)
+	return append(data, wdPadded...)
+}
+
+func utf16le(s string) []byte {
+	var b []byte
+	for _, u := range utf16.Encode([]rune(s)) {
+		b = binary.LittleEndian.AppendUint16(b, u)
+	}
+	return b
+}
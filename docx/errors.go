@@ -9,6 +9,7 @@ type Error string
 func (e Error) Error() string { return string(e) }
 
 const (
-	ErrInvalidDocument Error = "invalid document"
-	ErrNotADocument    Error = "not a document"
+	ErrEncryptedDocument Error = "encrypted document"
+	ErrInvalidDocument   Error = "invalid document"
+	ErrNotADocument      Error = "not a document"
 )
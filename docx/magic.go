@@ -2,7 +2,10 @@
 
 package docx
 
-import "bytes"
+import (
+	"bytes"
+	"encoding/binary"
+)
 
 // WordDocType represents the type of Word document.
 type WordDocType int
@@ -11,20 +14,51 @@ const (
 	Unknown WordDocType = iota
 	Doc                 // Word 97–2003 Documents
 	Docx                // Word 2007 and Later Documents
+	Odt                 // OpenDocument Text Documents
+	Rtf                 // Rich Text Format Documents
 )
 
 var (
 	docMagicNumber  = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
 	docxMagicNumber = []byte{0x50, 0x4B, 0x03, 0x04}
+	rtfMagicNumber  = []byte(`{\rtf`)
+	// OpenDocument files are zip files that must start with an uncompressed
+	// entry named "mimetype" holding the media type of the document.
+	odtMimeTypeName = []byte("mimetype")
+	odtMimeType     = []byte("application/vnd.oasis.opendocument.text")
 )
 
 // DetectWordDocType checks the initial bytes of a file to determine if it's a Word document.
 // Note: this is not a 100% accurate method since DOCX files share the same magic number as ZIP files.
+// ODT files are zip files, too, but they are identified by their mimetype entry.
 func DetectWordDocType(data []byte) WordDocType {
 	if bytes.HasPrefix(data, docMagicNumber) {
 		return Doc
 	} else if bytes.HasPrefix(data, docxMagicNumber) {
+		if isOdt(data) {
+			return Odt
+		}
 		return Docx
+	} else if bytes.HasPrefix(data, rtfMagicNumber) {
+		return Rtf
 	}
 	return Unknown
 }
+
+// isOdt returns true if the first entry in the zip file is the OpenDocument
+// mimetype for a text document. The entry must be stored uncompressed, so its
+// contents follow the name and extra fields of the local file header.
+func isOdt(data []byte) bool {
+	const headerSize = 30
+	if len(data) < headerSize {
+		return false
+	}
+	nameLen := int(binary.LittleEndian.Uint16(data[26:]))
+	extraLen := int(binary.LittleEndian.Uint16(data[28:]))
+	if len(data) < headerSize+nameLen+extraLen {
+		return false
+	} else if !bytes.Equal(data[headerSize:headerSize+nameLen], odtMimeTypeName) {
+		return false
+	}
+	return bytes.HasPrefix(data[headerSize+nameLen+extraLen:], odtMimeType)
+}
@@ -24,8 +24,10 @@ type UnitName_t string
 // ReportFile_t is the domain model for a report file.
 // All reports are owned by a clan and only that clan should have access to the report.
 //
-// The report file is the source of truth for the report, but we don't store the original report.
-// Instead, we store the normalized contents of the report because it is easier to work with.
+// The report file is the source of truth for the report. We store the original contents
+// so that the report can be parsed again after the parser is upgraded. We also store the
+// normalized lines because they are easier to work with. Each line remembers where it came
+// from in the original, so that diagnostics can point players at the right place.
 //
 // There are some errors that will prevent a report from being imported into the database.
 // All other errors are stored in the database with the report.
@@ -35,13 +37,22 @@ type UnitName_t string
 // It's probably rude of us, but bits of the report that have errors are not properly rendered.
 // For example, if there's an error with the turn number for a unit, the render will skip that unit.
 type ReportFile_t struct {
-	Owner ClanId_t  // clan that owns the report
-	Name  string    // name of the report file (includes the path and is unique within a clan)
-	Turn  TurnId_t  // turn number of the report (unique within a clan)
-	Units []*Unit_t // all units in the report, in the order they appear in the report
-	Error error     // highest level error encountered while parsing the report
-	Hash  string    // SHA1 hash of the report's original contents (unique within a clan)
-	Lines string    // report lines after normalization
+	Owner       ClanId_t        // clan that owns the report
+	Name        string          // name of the report file (includes the path and is unique within a clan)
+	Turn        TurnId_t        // turn number of the report (unique within a clan)
+	Units       []*Unit_t       // all units in the report, in the order they appear in the report
+	Error       error           // highest level error encountered while parsing the report
+	Hash        string          // SHA1 hash of the report's original contents (unique within a clan)
+	Original    []byte          // contents of the report file before extraction and normalization
+	Lines       []*ReportLine_t // report lines after normalization, without the empty lines
+	Fingerprint string          // SHA1 hash of the report's sections after normalization (survives re-saves and conversion to text)
+}
+
+// ReportLine_t is the domain model for a single line of a report after normalization.
+type ReportLine_t struct {
+	No     int    // 1-based line number after normalization
+	Source int    // 1-based line (text files) or paragraph (Word documents) number in the original
+	Text   string // the normalized line
 }
 
 // Turn_t is the domain model for a turn.
@@ -56,9 +67,52 @@ type Turn_t struct {
 // Unit_t is the domain model for a unit.
 // Unit data is owned by a clan and never shared.
 type Unit_t struct {
-	Id    UnitId_t   // unique identifier for the unit
-	Name  UnitName_t // optional name of the unit. mostly ignored.
-	Error error      // highest level error encountered while parsing the unit
+	Id        UnitId_t     // unique identifier for the unit
+	Name      UnitName_t   // optional name of the unit. mostly ignored.
+	Moves     []*Move_t    // steps of the unit's movement and its scouts' patrols, in order
+	Inventory *Inventory_t // people and goods at the end of the turn, if reported
+	Error     error        // highest level error encountered while parsing the unit
+}
+
+// Coordinates_t is the domain model for the location of a tile.
+type Coordinates_t struct {
+	Grid string // AA through ZZ, or ## if the grid is obscured
+	Col  int    // 1 ... 30
+	Row  int    // 1 ... 21
+}
+
+// Move_t is the domain model for a single step of a unit's movement.
+// A step that failed doesn't leave the starting tile.
+type Move_t struct {
+	Unit    UnitId_t      // unit that moved; scouts have their own ids, e.g. 0987s1
+	Step    int           // 1-based order of the step within the unit's movement
+	From    Coordinates_t // starting tile
+	Action  string        // STILL, SCOUT, or the direction of the step
+	To      Coordinates_t // ending tile
+	Terrain string        // code from the terrain_codes table for the ending tile
+	Failure string        // reason the step failed, empty if it didn't fail
+}
+
+// Inventory_t is the domain model for the people and goods that a unit has at the end of a turn.
+type Inventory_t struct {
+	Turn       TurnId_t
+	Unit       UnitId_t
+	Population *Population_t // nil if the report doesn't list the people in the unit
+	Items      []*Item_t     // goods, in the order they appear in the report
+}
+
+// Population_t is the domain model for the people in a unit.
+type Population_t struct {
+	People    int
+	Warriors  int
+	Actives   int
+	Inactives int
+}
+
+// Item_t is the domain model for a quantity of goods.
+type Item_t struct {
+	Code     string // code from the item_codes table, e.g. HORSES
+	Quantity int
 }
 
 func (t TurnId_t) YearMonth() (int, int) {
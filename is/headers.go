@@ -16,6 +16,9 @@ var (
 
 	rxTurnHeader = regexp.MustCompile(`^current turn \d{3,4}-\d{1,2}\(#\d+\),`)
 
+	rxInventoryHeading = regexp.MustCompile(`^(humans|population|possessions|inventory|goods|animals|minerals|war equipment|finished goods|finished items|raw materials|ships):?$`)
+	rxInventoryLine    = regexp.MustCompile(`^[a-z0-9][a-z0-9' ,:]*$`)
+
 	rxFleetMovement = regexp.MustCompile(`^(calm|mild|strong|gale) (ne|se|sw|nw|n|s) fleet movement:`)
 	rxScoutLine     = regexp.MustCompile(`^scout [1-8]:`)
 
@@ -33,6 +36,24 @@ func FleetMovement(line []byte) bool {
 	return rxFleetMovement.Match(line)
 }
 
+// InventoryHeading returns true if the line starts a block of people or goods.
+// Example: "humans" or "finished items:"
+//
+// Assumes that the line has already been cleaned up and converted to lower case.
+func InventoryHeading(line []byte) bool {
+	return rxInventoryHeading.Match(line)
+}
+
+// InventoryLine returns true if the line could be part of a block of people or goods.
+// The lines in a block are names and quantities, so we only check that the line
+// doesn't contain anything else and isn't a movement or status line.
+// Example: "cattle 300,horses 1,250" or "people warriors actives inactives"
+//
+// Assumes that the line has already been cleaned up and converted to lower case.
+func InventoryLine(line []byte) bool {
+	return rxInventoryLine.Match(line) && !MovementLine(line) && !UnitStatus(line)
+}
+
 // MovementLine returns true if the line represents a unit movement line.
 //
 // Assumes that the line has already been cleaned up and converted to lower case.
@@ -0,0 +1,58 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package lint
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// UnifiedDiff returns a unified diff of the original and corrected reports.
+//
+// The linter never adds or removes lines, so line N of the input always
+// matches line N of the output. That lets us skip the usual search for
+// the longest common subsequence.
+func (r *Result_t) UnifiedDiff(fromName, toName string, context int) []byte {
+	var changed []int
+	for n := range r.Input {
+		if !bytes.Equal(r.Input[n], r.Output[n]) {
+			changed = append(changed, n)
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+
+	b := &bytes.Buffer{}
+	_, _ = fmt.Fprintf(b, "--- %s\n+++ %s\n", fromName, toName)
+	for i := 0; i < len(changed); {
+		// extend the hunk while the next change is close enough to share context
+		j := i
+		for j+1 < len(changed) && changed[j+1]-changed[j] <= 2*context {
+			j++
+		}
+		start, end := max(changed[i]-context, 0), min(changed[j]+context+1, len(r.Input))
+		_, _ = fmt.Fprintf(b, "@@ -%d,%d +%d,%d @@\n", start+1, end-start, start+1, end-start)
+		for n := start; n < end; {
+			if bytes.Equal(r.Input[n], r.Output[n]) {
+				_, _ = fmt.Fprintf(b, " %s\n", r.Input[n])
+				n++
+				continue
+			}
+			// write a run of changed lines as removals followed by additions
+			run := n
+			for run < end && !bytes.Equal(r.Input[run], r.Output[run]) {
+				run++
+			}
+			for k := n; k < run; k++ {
+				_, _ = fmt.Fprintf(b, "-%s\n", r.Input[k])
+			}
+			for k := n; k < run; k++ {
+				_, _ = fmt.Fprintf(b, "+%s\n", r.Output[k])
+			}
+			n = run
+		}
+		i = j + 1
+	}
+	return b.Bytes()
+}
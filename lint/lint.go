@@ -0,0 +1,89 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package lint reports the mistakes in a turn report that the normalizer fixes.
+//
+// The normalizer silently rewrites the movement and status lines before they
+// are parsed. The linter applies the same rules, but records every change so
+// that players can review them and send the list back to the GM.
+package lint
+
+import (
+	"bytes"
+	"github.com/playbymail/tribal/is"
+	"github.com/playbymail/tribal/norm"
+)
+
+// Result_t is the result of linting a report.
+type Result_t struct {
+	Input  [][]byte      // lines from the original report
+	Output [][]byte      // corrected lines, one for every line in the input
+	Hits   []*norm.Hit_t // every change made, in line order
+}
+
+// Lint applies the normalizer rules to every movement and status line in the report.
+// Rules in the disabled map are skipped. Other lines are copied without changes.
+//
+// Internal rules, which only rewrite the GM's text into the form the parser
+// expects, are applied but not reported. Lines without a reported change are
+// copied without changes; the others are written back in the GM's form.
+//
+// The other rules expect the spaces to be normalized. Spacing that differs from
+// the GM's form is reported by the spaces rule. If that rule is disabled, lines
+// with spacing mistakes are copied without changes, so that the output never has
+// a change that isn't reported.
+//
+// Unlike section.Parser.Split, the linter doesn't force the report to lower case,
+// so the corrected report can be sent back to the GM.
+func Lint(input []byte, disabled map[string]bool) *Result_t {
+	r := &Result_t{}
+	for no, line := range bytes.Split(norm.LineEndings(input), []byte{'\n'}) {
+		r.Input = append(r.Input, line)
+		rules := rulesFor(norm.NormalizeCase(norm.NormalizeSpaces(line)))
+		if rules == nil {
+			r.Output = append(r.Output, line)
+			continue
+		}
+		changed := false
+		spaced := norm.Spaces.Apply(line)
+		if respaced := norm.Canonical(spaced); !bytes.Equal(respaced, line) {
+			if disabled[norm.Spaces.Name] {
+				r.Output = append(r.Output, line)
+				continue
+			}
+			r.Hits = append(r.Hits, &norm.Hit_t{Rule: norm.Spaces, Line: no + 1, Before: line, After: respaced})
+			changed = true
+		}
+		fixed, hits := norm.ApplyRules(no+1, spaced, rules, disabled)
+		for _, hit := range hits {
+			if !hit.Rule.Internal {
+				r.Hits, changed = append(r.Hits, hit), true
+			}
+		}
+		if !changed {
+			r.Output = append(r.Output, line)
+			continue
+		}
+		r.Output = append(r.Output, norm.Canonical(fixed))
+	}
+	return r
+}
+
+// Text returns the corrected report.
+func (r *Result_t) Text() []byte {
+	return bytes.Join(r.Output, []byte{'\n'})
+}
+
+// rulesFor returns the rules for the kind of line, or nil if the line isn't linted.
+// Expects the line to be normalized and lower case.
+func rulesFor(line []byte) []*norm.Rule_t {
+	if is.FleetMovement(line) {
+		return norm.FleetMovementRules
+	} else if is.ScoutLine(line) {
+		return norm.ScoutMovementRules
+	} else if is.TribeMovement(line) {
+		return norm.TribeMovementRules
+	} else if is.UnitStatus(line) {
+		return norm.UnitStatusRules
+	}
+	return nil
+}
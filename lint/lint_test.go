@@ -0,0 +1,127 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package lint_test
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/playbymail/tribal/lint"
+	"github.com/playbymail/tribal/norm"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLint(t *testing.T) {
+	input := []byte("Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0409)\n" +
+		"Current Turn 900-05 (#5), Summer, FINE\n" +
+		"Tribe Movement: Move NE-PR\\-SE-PR,\\SE-GH\\\\\n" +
+		"Scout 1: Scout N-GH,,Nothing of interest found\n" +
+		"0987 Status: PRAIRIE,O N,NE\\0987c1,0987c2\n")
+
+	r := lint.Lint(input, nil)
+	for n, want := range []string{
+		"Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0409)",
+		"Current Turn 900-05 (#5), Summer, FINE",
+		"Tribe Movement: Move NE-PR\\SE-PR\\SE-GH",
+		"Scout 1: Scout N-GH,Nothing of interest found",
+		"0987 Status: PRAIRIE,O N NE,0987c1 0987c2",
+		"",
+	} {
+		if got := string(r.Output[n]); got != want {
+			t.Errorf("line %d: want %q, got %q", n+1, want, got)
+		}
+	}
+
+	var got []string
+	for _, hit := range r.Hits {
+		got = append(got, hit.Rule.Name)
+	}
+	want := []string{"backslash-dash", "comma-backslash", "backslash-run", "trailing-backslash", "comma-run", "backslash-unit", "direction-list", "unit-list"}
+	if len(got) != len(want) {
+		t.Fatalf("hits: want %v, got %v", want, got)
+	}
+	for n := range want {
+		if got[n] != want[n] {
+			t.Errorf("hit %d: want %q, got %q", n+1, want[n], got[n])
+		}
+	}
+	if r.Hits[0].Line != 3 {
+		t.Errorf("hit 1: want line 3, got %d", r.Hits[0].Line)
+	}
+
+	diff := string(r.UnifiedDiff("a", "b", 1))
+	wantDiff := "--- a\n+++ b\n@@ -2,5 +2,5 @@\n" +
+		" Current Turn 900-05 (#5), Summer, FINE\n" +
+		"-Tribe Movement: Move NE-PR\\-SE-PR,\\SE-GH\\\\\n" +
+		"-Scout 1: Scout N-GH,,Nothing of interest found\n" +
+		"-0987 Status: PRAIRIE,O N,NE\\0987c1,0987c2\n" +
+		"+Tribe Movement: Move NE-PR\\SE-PR\\SE-GH\n" +
+		"+Scout 1: Scout N-GH,Nothing of interest found\n" +
+		"+0987 Status: PRAIRIE,O N NE,0987c1 0987c2\n" +
+		" \n"
+	if diff != wantDiff {
+		t.Errorf("diff: want\n%s\ngot\n%s", wantDiff, diff)
+	}
+}
+
+// TestLintClean verifies that reports in the GM's canonical form don't have any hits,
+// and that the corrected report is the same as the input. The GM doesn't always
+// space the delimiters the same way, so the spaces rule may report those lines.
+func TestLintClean(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "section", "testdata", "*.report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) == 0 {
+		t.Fatal("no reports found")
+	}
+	for _, path := range paths {
+		input, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, hit := range lint.Lint(input, nil).Hits {
+			if hit.Rule != norm.Spaces {
+				t.Errorf("%s:%d: %s: %q", path, hit.Line, hit.Rule.Name, hit.Before)
+			}
+		}
+		r := lint.Lint(input, map[string]bool{norm.Spaces.Name: true})
+		for _, hit := range r.Hits {
+			t.Errorf("%s:%d: %s: %q", path, hit.Line, hit.Rule.Name, hit.Before)
+		}
+		if !bytes.Equal(r.Text(), input) {
+			t.Errorf("%s: output differs from input", path)
+		}
+	}
+}
+
+// TestLintSpaces verifies that spacing changes are reported, and that disabling
+// the spaces rule leaves the lines that need it alone.
+func TestLintSpaces(t *testing.T) {
+	input := []byte("Tribe Movement: Move NE-PR,  O S W\\SE-GH\\\n" +
+		"Tribe Movement: Move NE-PR,O S W\\SE-GH\\\n")
+
+	r := lint.Lint(input, nil)
+	for n, want := range []string{
+		"Tribe Movement: Move NE-PR,O S W\\SE-GH",
+		"Tribe Movement: Move NE-PR,O S W\\SE-GH",
+	} {
+		if got := string(r.Output[n]); got != want {
+			t.Errorf("line %d: want %q, got %q", n+1, want, got)
+		}
+	}
+	var got []string
+	for _, hit := range r.Hits {
+		got = append(got, fmt.Sprintf("%d:%s", hit.Line, hit.Rule.Name))
+	}
+	if want := "[1:spaces 1:trailing-backslash 2:trailing-backslash]"; fmt.Sprint(got) != want {
+		t.Errorf("hits: want %s, got %v", want, got)
+	}
+
+	r = lint.Lint(input, map[string]bool{norm.Spaces.Name: true})
+	if got, want := string(r.Output[0]), string(r.Input[0]); got != want {
+		t.Errorf("disabled: line 1: want %q, got %q", want, got)
+	} else if len(r.Hits) != 1 || r.Hits[0].Line != 2 {
+		t.Errorf("disabled: hits: want 1 on line 2, got %d", len(r.Hits))
+	}
+}
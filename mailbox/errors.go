@@ -0,0 +1,14 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package mailbox
+
+// Error defines a constant error
+type Error string
+
+// Error implements the Errors interface
+func (e Error) Error() string { return string(e) }
+
+const (
+	ErrInvalidMessage Error = "invalid message"
+	ErrNoMessages     Error = "no messages"
+)
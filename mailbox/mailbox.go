@@ -0,0 +1,228 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package mailbox extracts attachments from saved email.
+//
+// It reads single messages (.eml) and mbox files. Everything is done
+// offline; there is no support for talking to a mail server.
+package mailbox
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Attachment_t is a file attached to a message.
+type Attachment_t struct {
+	Message int    // 1-based index of the message in the file
+	Subject string // subject of the message, for logging
+	Name    string // file name of the attachment, without any directories
+	Data    []byte // decoded contents of the attachment
+}
+
+// ReadFile loads a mailbox or a single message from a file and returns the attachments.
+func ReadFile(path string) ([]*Attachment_t, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Read(data)
+}
+
+// Read returns the attachments from every message in the data.
+// The data is treated as an mbox file if it starts with a "From " line.
+// Otherwise, it is treated as a single message.
+func Read(data []byte) ([]*Attachment_t, error) {
+	var messages [][]byte
+	if bytes.HasPrefix(data, []byte("From ")) {
+		messages = splitMbox(data)
+	} else {
+		messages = [][]byte{data}
+	}
+	if len(messages) == 0 {
+		return nil, ErrNoMessages
+	}
+	var attachments []*Attachment_t
+	for n, message := range messages {
+		msg, err := mail.ReadMessage(bytes.NewReader(message))
+		if err != nil {
+			return attachments, errors.Join(ErrInvalidMessage, fmt.Errorf("message %d: %w", n+1, err))
+		}
+		subject := msg.Header.Get("Subject")
+		if s, err := new(mime.WordDecoder).DecodeHeader(subject); err == nil {
+			subject = s
+		}
+		list, err := walk(msg.Header, msg.Body)
+		if err != nil {
+			return attachments, errors.Join(ErrInvalidMessage, fmt.Errorf("message %d: %w", n+1, err))
+		}
+		for _, a := range list {
+			a.Message, a.Subject = n+1, subject
+		}
+		attachments = append(attachments, list...)
+	}
+	return attachments, nil
+}
+
+// splitMbox splits an mbox file into messages. Each message starts with a
+// "From " line, which is not part of the message. Lines in the body that
+// were quoted as ">From " are restored.
+func splitMbox(data []byte) [][]byte {
+	var messages [][]byte
+	var message []byte
+	inMessage := false
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(nil, len(data)+1)
+	sc.Split(scanLines)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if bytes.HasPrefix(line, []byte("From ")) {
+			if inMessage {
+				messages = append(messages, message)
+			}
+			message, inMessage = nil, true
+			continue
+		}
+		if quoted := bytes.TrimLeft(line, ">"); len(quoted) < len(line) && bytes.HasPrefix(quoted, []byte("From ")) {
+			line = line[1:]
+		}
+		message = append(message, line...)
+	}
+	if inMessage {
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+// scanLines is like bufio.ScanLines, but it keeps the line endings.
+func scanLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		return i + 1, data[:i+1], nil
+	} else if atEOF && len(data) != 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// header is the part of the header that walk needs.
+// Both mail.Header and the header of a multipart.Part implement it.
+type header interface {
+	Get(key string) string
+}
+
+// walk returns the attachments in a message or a part of a message.
+// Multipart bodies are walked recursively, so forwarded messages and
+// attachments inside multipart/alternative or multipart/related are found.
+func walk(h header, body io.Reader) ([]*Attachment_t, error) {
+	mediaType, params, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		// RFC 2045 says a missing or broken content type is plain text
+		mediaType, params = "text/plain", nil
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		boundary := params["boundary"]
+		if boundary == "" {
+			return nil, errors.New("multipart without boundary")
+		}
+		var attachments []*Attachment_t
+		mr := multipart.NewReader(body, boundary)
+		for {
+			part, err := mr.NextRawPart()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return attachments, err
+			}
+			list, err := walk(part.Header, part)
+			if err != nil {
+				return attachments, err
+			}
+			attachments = append(attachments, list...)
+		}
+		return attachments, nil
+	} else if mediaType == "message/rfc822" {
+		msg, err := mail.ReadMessage(decode(h, body))
+		if err != nil {
+			return nil, err
+		}
+		return walk(msg.Header, msg.Body)
+	}
+
+	name := fileName(h, params)
+	if name == "" {
+		// not an attachment, probably the text of the message
+		return nil, nil
+	}
+	data, err := io.ReadAll(decode(h, body))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return []*Attachment_t{{Name: name, Data: data}}, nil
+}
+
+// fileName returns the name of an attachment from the Content-Disposition
+// header or the name parameter of the Content-Type header.
+// Encoded words are decoded and any directories are removed.
+// Returns an empty string if the part doesn't have a name.
+func fileName(h header, params map[string]string) string {
+	var name string
+	if _, dparams, err := mime.ParseMediaType(h.Get("Content-Disposition")); err == nil {
+		name = dparams["filename"]
+	}
+	if name == "" {
+		name = params["name"]
+	}
+	if name == "" {
+		return ""
+	}
+	if s, err := new(mime.WordDecoder).DecodeHeader(name); err == nil {
+		name = s
+	}
+	// some mailers send Windows paths
+	name = strings.ReplaceAll(name, `\`, "/")
+	return filepath.Base(name)
+}
+
+// decode returns a reader that undoes the Content-Transfer-Encoding of the body.
+func decode(h header, body io.Reader) io.Reader {
+	switch strings.ToLower(strings.TrimSpace(h.Get("Content-Transfer-Encoding"))) {
+	case "base64":
+		return base64.NewDecoder(base64.StdEncoding, &base64Cleaner{r: body})
+	case "quoted-printable":
+		return quotedprintable.NewReader(body)
+	}
+	return body
+}
+
+// base64Cleaner drops the line endings and other white space that mailers
+// put in base64 bodies, since the base64 decoder only ignores CR and LF.
+type base64Cleaner struct {
+	r io.Reader
+}
+
+func (c *base64Cleaner) Read(p []byte) (int, error) {
+	for {
+		n, err := c.r.Read(p)
+		j := 0
+		for _, ch := range p[:n] {
+			if ch != ' ' && ch != '\t' && ch != '\r' && ch != '\n' {
+				p[j] = ch
+				j++
+			}
+		}
+		if j != 0 || err != nil {
+			return j, err
+		}
+	}
+}
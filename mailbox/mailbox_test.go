@@ -0,0 +1,69 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package mailbox_test
+
+import (
+	"github.com/playbymail/tribal/mailbox"
+	"strings"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	const mbox = `From gm@example.com Sat Jan  4 12:00:00 2025
+From: GM <gm@example.com>
+To: clan0987@example.com
+Subject: Turn 900-05 results
+MIME-Version: 1.0
+Content-Type: multipart/mixed; boundary="outer"
+
+--outer
+Content-Type: multipart/alternative; boundary="inner"
+
+--inner
+Content-Type: text/plain; charset=utf-8
+
+Your results are attached.
+>From the GM, with love.
+--inner--
+
+--outer
+Content-Type: application/vnd.openxmlformats-officedocument.wordprocessingml.document; name="0900-05.0987.report.docx"
+Content-Disposition: attachment; filename="0900-05.0987.report.docx"
+Content-Transfer-Encoding: base64
+
+UEsDBHJl
+cG9ydA==
+--outer--
+
+From clan0987@example.com Sun Jan  5 12:00:00 2025
+From: Clan 0987 <clan0987@example.com>
+Subject: =?utf-8?q?Fwd=3A_caf=C3=A9?=
+Content-Type: multipart/mixed; boundary="b1"
+
+--b1
+Content-Type: text/plain; charset=windows-1252
+Content-Disposition: attachment; filename="=?utf-8?q?Caf=C3=A9_report.txt?="
+Content-Transfer-Encoding: quoted-printable
+
+Tribe 0987, , Current Hex =3D KP 0608
+>From here
+--b1--
+`
+	got, err := mailbox.Read([]byte(strings.ReplaceAll(mbox, "\n", "\r\n")))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want := []mailbox.Attachment_t{
+		{Message: 1, Subject: "Turn 900-05 results", Name: "0900-05.0987.report.docx", Data: []byte("PK\x03\x04report")},
+		{Message: 2, Subject: "Fwd: café", Name: "Café report.txt", Data: []byte("Tribe 0987, , Current Hex = KP 0608\r\nFrom here")},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Read: want %d attachments, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.Message != w.Message || g.Subject != w.Subject || g.Name != w.Name || string(g.Data) != string(w.Data) {
+			t.Errorf("attachment %d: want %d %q %q %q, got %d %q %q %q", i+1, w.Message, w.Subject, w.Name, w.Data, g.Message, g.Subject, g.Name, g.Data)
+		}
+	}
+}
@@ -19,8 +19,8 @@ func RemoveBadUtf8(input []byte) []byte {
 	for i := 0; i < len(input); {
 		// if the encoding is invalid, DecodeRune returns (RuneError, 1).
 		// otherwise, it returns (r, w) where r is the rune and w is the width of the run, in bytes.
-		r, w := utf8.DecodeRune(input)
-		if r == utf8.RuneError {
+		r, w := utf8.DecodeRune(input[i:])
+		if r == utf8.RuneError && w == 1 {
 			// invalid sequence; copy replacement character
 			output = append(output, runeErrorByte...)
 		} else {
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package norm_test
+
+import (
+	"github.com/playbymail/tribal/norm"
+	"testing"
+)
+
+func TestRemoveBadUtf8(t *testing.T) {
+	for _, tc := range []struct {
+		input, want string
+	}{
+		{"Café “Los Angeles”", "Café “Los Angeles”"},
+		{"Caf\xe9 ok", "Caf� ok"},
+		{"é\xff€", "é�€"},
+	} {
+		if got := string(norm.RemoveBadUtf8([]byte(tc.input))); got != tc.want {
+			t.Errorf("RemoveBadUtf8(%q): want %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestPrintingGlyphs(t *testing.T) {
+	for _, tc := range []struct {
+		input, want string
+	}{
+		{"Café\t“Los Angeles”\n", "Café “Los Angeles”\n"},
+		{"a b\xffc\x00d", "a b c d"},
+	} {
+		if got := string(norm.PrintingGlyphs([]byte(tc.input))); got != tc.want {
+			t.Errorf("PrintingGlyphs(%q): want %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
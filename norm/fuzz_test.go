@@ -0,0 +1,75 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package norm_test
+
+import (
+	"bytes"
+	"github.com/playbymail/tribal/norm"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// seedCorpus adds every line from the section regression corpus to the fuzzer.
+// the lines are normalized the same way that section.Parser.Split normalizes them.
+func seedCorpus(f *testing.F, prefix string) {
+	paths, err := filepath.Glob(filepath.Join("..", "section", "testdata", "*.report.txt"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		data = norm.LineEndings(norm.NormalizeCase(norm.NormalizeSpaces(data)))
+		for _, line := range bytes.Split(data, []byte{'\n'}) {
+			if bytes.HasPrefix(line, []byte(prefix)) {
+				f.Add(line)
+			}
+		}
+	}
+}
+
+// checkPunctuation verifies the invariants shared by the movement normalizers.
+func checkPunctuation(t *testing.T, input, output []byte) {
+	if len(output) > len(input) {
+		t.Errorf("output longer than input: %q -> %q", input, output)
+	}
+	if bytes.Contains(output, []byte{'\\', '\\'}) {
+		t.Errorf("output contains run of backslashes: %q -> %q", input, output)
+	}
+	if bytes.HasSuffix(output, []byte{'\\'}) {
+		t.Errorf("output ends with backslash: %q -> %q", input, output)
+	}
+}
+
+func FuzzFleetMovement(f *testing.F) {
+	f.Add([]byte(`mild ne fleet movement:move ne-o,\-(ne o,se o,sw o,n o,nw o,s o)\\`))
+	seedCorpus(f, "mild ")
+	f.Fuzz(func(t *testing.T, input []byte) {
+		output := norm.FleetMovement(bytes.Clone(input))
+		checkPunctuation(t, input, output)
+	})
+}
+
+func FuzzScoutMovement(f *testing.F) {
+	f.Add([]byte(`scout 1:scout n-gh\\n-sw,\not enough m.p's to move to n into prairie,nothing of interest found`))
+	seedCorpus(f, "scout ")
+	f.Fuzz(func(t *testing.T, input []byte) {
+		output := norm.ScoutMovement(bytes.Clone(input))
+		checkPunctuation(t, input, output)
+		if bytes.HasSuffix(output, []byte{','}) {
+			t.Errorf("output ends with comma: %q -> %q", input, output)
+		}
+	})
+}
+
+func FuzzTribeMovement(f *testing.F) {
+	f.Add([]byte(`tribe movement:move ne-pr\-se-pr 0987c1`))
+	seedCorpus(f, "tribe movement:")
+	f.Fuzz(func(t *testing.T, input []byte) {
+		output := norm.TribeMovement(bytes.Clone(input))
+		checkPunctuation(t, input, output)
+	})
+}
@@ -2,6 +2,11 @@
 
 package norm
 
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
 var (
 	// pre-computed lookup table for acceptable printing characters
 	isPrintingGlyph [256]bool
@@ -17,11 +22,27 @@ func init() {
 
 // PrintingGlyphs returns the slice with all non-printing characters
 // replace with spaces. Updates the input slice in-place.
+// Printable non-ASCII characters, like accented letters and smart quotes,
+// are kept. Each non-printing multi-byte character becomes a single space,
+// so the result may be shorter than the input.
 func PrintingGlyphs(input []byte) []byte {
-	for i := 0; i < len(input); i++ {
-		if !isPrintingGlyph[input[i]] {
-			input[i] = ' '
+	output := input[:0]
+	for i := 0; i < len(input); {
+		if ch := input[i]; ch < utf8.RuneSelf {
+			if !isPrintingGlyph[ch] {
+				ch = ' '
+			}
+			output = append(output, ch)
+			i++
+			continue
+		}
+		r, w := utf8.DecodeRune(input[i:])
+		if r == utf8.RuneError || !unicode.IsGraphic(r) || unicode.IsSpace(r) {
+			output = append(output, ' ')
+		} else {
+			output = append(output, input[i:i+w]...)
 		}
+		i += w
 	}
-	return input
+	return output
 }
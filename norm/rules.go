@@ -0,0 +1,227 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package norm
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// Rule_t is a named fix for a common mistake in the movement and status lines.
+//
+// The rules are applied in order, and each rule expects the spaces in the line
+// to have been normalized. The rules don't care about case; they preserve it so
+// that the linter can write out a corrected report that the GM can read.
+//
+// Internal rules rewrite the text that the GM writes into the form that the
+// parser expects. They aren't mistakes, so the linter doesn't report them.
+type Rule_t struct {
+	Name     string // short name, used to enable or disable the rule
+	Descr    string // description of the mistake that the rule fixes
+	Internal bool   // true if the rule only rewrites canonical text for the parser
+	fix      func(line []byte) []byte
+}
+
+// Hit_t records a line that was changed by a rule.
+type Hit_t struct {
+	Rule   *Rule_t
+	Line   int    // line number in the input, 1-based
+	Before []byte // text of the line before the rule was applied
+	After  []byte // text of the line after the rule was applied
+}
+
+var (
+	// Spaces is not applied by the movement normalizers because section.Parser.Split
+	// normalizes the spaces in the entire report before splitting it. It also removes
+	// the space that the GM writes after a colon; Canonical puts that back.
+	Spaces = &Rule_t{Name: "spaces", Descr: "runs of spaces or spaces around delimiters", fix: NormalizeSpaces}
+
+	ruleMoveBackslash = &Rule_t{Name: "move-backslash", Descr: "space instead of backslash after tribe movement", Internal: true, fix: func(line []byte) []byte {
+		return reMoveSpace.ReplaceAll(line, []byte(`$1\`))
+	}}
+	ruleScoutBackslash = &Rule_t{Name: "scout-backslash", Descr: "space instead of backslash after scout", Internal: true, fix: func(line []byte) []byte {
+		return reScoutSpace.ReplaceAll(line, []byte(`$1\`))
+	}}
+	ruleBackslashDash = &Rule_t{Name: "backslash-dash", Descr: "dash after backslash", fix: func(line []byte) []byte {
+		return reBackslashDash.ReplaceAll(line, []byte{'\\'})
+	}}
+	ruleBackslashComma = &Rule_t{Name: "backslash-comma", Descr: "comma after backslash", fix: func(line []byte) []byte {
+		return reBackslashComma.ReplaceAll(line, []byte{'\\'})
+	}}
+	ruleCommaBackslash = &Rule_t{Name: "comma-backslash", Descr: "comma before backslash", fix: func(line []byte) []byte {
+		return reCommaBackslash.ReplaceAll(line, []byte{'\\'})
+	}}
+	ruleBackslashUnit = &Rule_t{Name: "backslash-unit", Descr: "backslash instead of comma before unit id", fix: func(line []byte) []byte {
+		return reBackslashUnit.ReplaceAll(line, []byte{',', '$', '1'})
+	}}
+	ruleDirectionUnit = &Rule_t{Name: "direction-unit", Descr: "space instead of comma between direction and unit id", fix: func(line []byte) []byte {
+		return reDirectionUnit.ReplaceAll(line, []byte{'$', '1', ',', '$', '2'})
+	}}
+	ruleRunOfBackslashes = &Rule_t{Name: "backslash-run", Descr: "run of backslashes", fix: func(line []byte) []byte {
+		return reRunOfBackslashes.ReplaceAll(line, []byte{'\\'})
+	}}
+	ruleRunOfCommas = &Rule_t{Name: "comma-run", Descr: "run of commas", fix: func(line []byte) []byte {
+		return reRunOfComma.ReplaceAll(line, []byte{','})
+	}}
+	ruleObservationComma = &Rule_t{Name: "observation-comma", Descr: "comma before closing parenthesis in fleet observations", fix: func(line []byte) []byte {
+		return bytes.ReplaceAll(line, []byte{',', ')'}, []byte{')'})
+	}}
+	ruleNothingOfInterest = &Rule_t{Name: "nothing-of-interest", Descr: "comma instead of backslash before nothing of interest found", Internal: true, fix: func(line []byte) []byte {
+		return replaceFirstSeparator(line, reCommaNothingOfInterest)
+	}}
+	rulePatrolledAndFound = &Rule_t{Name: "patrolled-and-found", Descr: "comma instead of backslash before patrolled and found", Internal: true, fix: func(line []byte) []byte {
+		return replaceFirstSeparator(line, reCommaPatrolledAndFound)
+	}}
+	ruleFindQtyItem = &Rule_t{Name: "find-quantity-item", Descr: "comma instead of backslash before find quantity item", fix: func(line []byte) []byte {
+		return replaceAllSeparators(line, reCommaFindQtyItem)
+	}}
+	ruleNoGroups = &Rule_t{Name: "no-groups", Descr: "comma instead of backslash before no groups located", fix: func(line []byte) []byte {
+		return replaceAllSeparators(line, reCommaNoGroups)
+	}}
+	ruleTrailingBackslash = &Rule_t{Name: "trailing-backslash", Descr: "backslash at end of line", fix: func(line []byte) []byte {
+		return bytes.TrimRight(line, "\\")
+	}}
+	ruleTrailingSeparators = &Rule_t{Name: "trailing-separators", Descr: "backslash or comma at end of line", fix: func(line []byte) []byte {
+		return bytes.TrimRight(line, "\\,")
+	}}
+	ruleDirectionList = &Rule_t{Name: "direction-list", Descr: "comma instead of space between directions", fix: func(line []byte) []byte {
+		return ListOfDirections(bytes.Clone(line))
+	}}
+	ruleUnitList = &Rule_t{Name: "unit-list", Descr: "comma instead of space between unit ids", fix: func(line []byte) []byte {
+		return ListOfUnitIDs(bytes.Clone(line))
+	}}
+
+	// FleetMovementRules are the rules applied by FleetMovement.
+	FleetMovementRules = []*Rule_t{
+		ruleBackslashDash,
+		ruleBackslashComma,
+		ruleCommaBackslash,
+		ruleBackslashUnit,
+		ruleDirectionUnit,
+		ruleRunOfBackslashes,
+		ruleRunOfCommas,
+		ruleObservationComma,
+		ruleTrailingBackslash,
+	}
+
+	// ScoutMovementRules are the rules applied by ScoutMovement.
+	ScoutMovementRules = []*Rule_t{
+		ruleScoutBackslash,
+		ruleBackslashDash,
+		ruleBackslashComma,
+		ruleCommaBackslash,
+		ruleBackslashUnit,
+		ruleDirectionUnit,
+		ruleRunOfBackslashes,
+		ruleRunOfCommas,
+		ruleNothingOfInterest,
+		rulePatrolledAndFound,
+		ruleFindQtyItem,
+		ruleNoGroups,
+		ruleTrailingSeparators,
+		ruleDirectionList,
+		ruleUnitList,
+	}
+
+	// TribeMovementRules are the rules applied by TribeMovement.
+	TribeMovementRules = []*Rule_t{
+		ruleMoveBackslash,
+		ruleBackslashDash,
+		ruleBackslashComma,
+		ruleCommaBackslash,
+		ruleBackslashUnit,
+		ruleDirectionUnit,
+		ruleRunOfBackslashes,
+		ruleRunOfCommas,
+		ruleTrailingBackslash,
+		ruleDirectionList,
+		ruleUnitList,
+	}
+
+	// UnitStatusRules are the rules applied by UnitStatus.
+	UnitStatusRules = []*Rule_t{
+		ruleBackslashDash,
+		ruleBackslashComma,
+		ruleCommaBackslash,
+		ruleBackslashUnit,
+		ruleDirectionUnit,
+		ruleRunOfBackslashes,
+		ruleRunOfCommas,
+		ruleTrailingBackslash,
+		ruleDirectionList,
+		ruleUnitList,
+	}
+)
+
+// Rules returns every rule that the linter reports, in the order they are first applied.
+// Internal rules are not included.
+func Rules() []*Rule_t {
+	var list []*Rule_t
+	seen := map[string]bool{}
+	for _, rules := range [][]*Rule_t{{Spaces}, ScoutMovementRules, TribeMovementRules, FleetMovementRules, UnitStatusRules} {
+		for _, rule := range rules {
+			if !rule.Internal && !seen[rule.Name] {
+				list, seen[rule.Name] = append(list, rule), true
+			}
+		}
+	}
+	return list
+}
+
+// Apply applies the rule to the line and returns the result.
+func (r *Rule_t) Apply(line []byte) []byte {
+	return r.fix(line)
+}
+
+// ApplyRules applies the rules, in order, to the line.
+// Rules that are in the disabled map are skipped.
+// Returns the updated line and a hit for every rule that changed it.
+func ApplyRules(lineNo int, line []byte, rules []*Rule_t, disabled map[string]bool) ([]byte, []*Hit_t) {
+	var hits []*Hit_t
+	for _, rule := range rules {
+		if disabled[rule.Name] {
+			continue
+		}
+		after := rule.fix(line)
+		if !bytes.Equal(line, after) {
+			hits = append(hits, &Hit_t{Rule: rule, Line: lineNo, Before: line, After: after})
+		}
+		line = after
+	}
+	return line, hits
+}
+
+// Canonical reverses the internal rules and puts back the space after a colon,
+// returning the text that the GM writes.
+// Expects the spaces in the line to have been normalized. Case is preserved.
+//
+//	"Scout 1:Scout\N-GH\Nothing of interest found" -> "Scout 1: Scout N-GH,Nothing of interest found"
+func Canonical(line []byte) []byte {
+	line = reInternalLead.ReplaceAll(line, []byte(`$1 `))
+	line = reInternalColon.ReplaceAll(line, []byte(`: $1`))
+	return reInternalSeparator.ReplaceAll(line, []byte(`,$1`))
+}
+
+// replaceFirstSeparator replaces the comma at the start of the first match with a backslash.
+func replaceFirstSeparator(line []byte, re *regexp.Regexp) []byte {
+	loc := re.FindIndex(line)
+	if loc == nil {
+		return line
+	}
+	line = bytes.Clone(line)
+	line[loc[0]] = '\\'
+	return line
+}
+
+// replaceAllSeparators replaces the comma at the start of every match with a backslash.
+func replaceAllSeparators(line []byte, re *regexp.Regexp) []byte {
+	locs := re.FindAllIndex(line, -1)
+	if locs == nil {
+		return line
+	}
+	line = bytes.Clone(line)
+	for _, loc := range locs {
+		line[loc[0]] = '\\'
+	}
+	return line
+}
@@ -0,0 +1,28 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package norm_test
+
+import (
+	"github.com/playbymail/tribal/norm"
+	"testing"
+)
+
+// TestCanonical verifies that Canonical reverses the internal rules.
+func TestCanonical(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		rules []*norm.Rule_t
+	}{
+		{`Tribe Movement: Move`, norm.TribeMovementRules},
+		{`Tribe Movement: Move NE-PR\SE-PR,O S W`, norm.TribeMovementRules},
+		{`Scout 1: Scout N-GH\N-SW\Not enough M.P's to move to N into PRAIRIE,Nothing of interest found`, norm.ScoutMovementRules},
+		{`Scout 7: Scout NW-RH\N-GH\N-PR,O NW N,3987,Can't move on Ocean to N of HEX,Patrolled and found 3987`, norm.ScoutMovementRules},
+		{`0987 Status: PRAIRIE,0987`, norm.UnitStatusRules},
+	} {
+		line := norm.Spaces.Apply([]byte(tc.input))
+		line, _ = norm.ApplyRules(0, line, tc.rules, nil)
+		if got := string(norm.Canonical(line)); got != tc.input {
+			t.Errorf("%q: got %q", tc.input, got)
+		}
+	}
+}
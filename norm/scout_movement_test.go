@@ -0,0 +1,24 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package norm_test
+
+import (
+	"github.com/playbymail/tribal/norm"
+	"testing"
+)
+
+// TestScoutMovement verifies that the first step of a patrol is split from
+// the scout label even when the GM used a space instead of a backslash.
+func TestScoutMovement(t *testing.T) {
+	for _, tc := range []struct {
+		input, want string
+	}{
+		{`scout 1:scout n-pr\ne-pr`, `scout 1:scout\n-pr\ne-pr`},
+		{`scout 1:scout\n-pr\ne-pr`, `scout 1:scout\n-pr\ne-pr`},
+		{`scout 1:scout`, `scout 1:scout`},
+	} {
+		if got := string(norm.ScoutMovement([]byte(tc.input))); got != tc.want {
+			t.Errorf("%q: want %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
@@ -3,25 +3,31 @@
 package norm
 
 import (
-	"bytes"
 	"regexp"
 )
 
 var (
 	reBackslashDash = regexp.MustCompile(`\\+-+ *`)
 
-	reBackslashComma   = regexp.MustCompile(`\\+,+`)
-	reBackslashUnit    = regexp.MustCompile(`\\+(\d{4}(?:[cefg]\d)?)`)
-	reCommaBackslash   = regexp.MustCompile(`,+\\`)
-	reCommaFindQtyItem = regexp.MustCompile(`,find [1-9]\d* `)
-	reCommaNoGroups    = regexp.MustCompile(`,no groups located`)
-	reDirectionUnit    = regexp.MustCompile(`\b(ne|se|sw|nw|n|s) (\d{4}(?:[cefg]\d)?)`)
+	reBackslashComma         = regexp.MustCompile(`\\+,+`)
+	reBackslashUnit          = regexp.MustCompile(`(?i)\\+(\d{4}(?:[cefg]\d)?)`)
+	reCommaBackslash         = regexp.MustCompile(`,+\\`)
+	reCommaFindQtyItem       = regexp.MustCompile(`(?i),find [1-9]\d* `)
+	reCommaNoGroups          = regexp.MustCompile(`(?i),no groups located`)
+	reCommaNothingOfInterest = regexp.MustCompile(`(?i),nothing of interest found`)
+	reCommaPatrolledAndFound = regexp.MustCompile(`(?i),patrolled and found `)
+	reDirectionUnit          = regexp.MustCompile(`(?i)\b(ne|se|sw|nw|n|s) (\d{4}(?:[cefg]\d)?)`)
+	reInternalColon          = regexp.MustCompile(`:(\S)`)
+	reInternalLead           = regexp.MustCompile(`^((?i)tribe movement:move|scout [1-8]:scout)\\`)
+	reInternalSeparator      = regexp.MustCompile(`(?i)\\(nothing of interest found|patrolled and found )`)
+	reMoveSpace              = regexp.MustCompile(`^((?i)tribe movement:move) `)
+	reScoutSpace             = regexp.MustCompile(`^((?i)scout [1-8]:scout) `)
 
 	// matches space direction comma
-	reSpaceDirectionCommaDirection = regexp.MustCompile(` (nw|ne|n|sw|se|s),(?:nw|ne|n|sw|se|s)([,\\]|$)`)
+	reSpaceDirectionCommaDirection = regexp.MustCompile(`(?i) (nw|ne|n|sw|se|s),(?:nw|ne|n|sw|se|s)([,\\]|$)`)
 
 	// matches a unit ID followed by comma followed by another unit ID
-	reUnitCommaUnit = regexp.MustCompile(`([0-9]{4}(?:[cefg][1-9])?),([0-9]{4}(?:[cefg][1-9])?)`)
+	reUnitCommaUnit = regexp.MustCompile(`(?i)([0-9]{4}(?:[cefg][1-9])?),([0-9]{4}(?:[cefg][1-9])?)`)
 
 	reRunOfBackslashes = regexp.MustCompile(`\\\\+`)
 	reRunOfComma       = regexp.MustCompile(`,,+`)
@@ -31,27 +37,7 @@ var (
 // or direction followed by a unit ID. Caller must have already compressed spaces
 // on the input line and forced to lowercase.
 func FleetMovement(line []byte) []byte {
-	// replace backslash+dash with backslash
-	line = reBackslashDash.ReplaceAll(line, []byte{'\\'})
-
-	// replace backslash+comma and comma+backslash with backslash
-	line = reBackslashComma.ReplaceAll(line, []byte{'\\'})
-	line = reCommaBackslash.ReplaceAll(line, []byte{'\\'})
-
-	// fix issues with backslash or direction followed by a unit ID
-	line = reBackslashUnit.ReplaceAll(line, []byte{',', '$', '1'})
-	line = reDirectionUnit.ReplaceAll(line, []byte{'$', '1', ',', '$', '2'})
-
-	// reduce runs of certain punctuation to a single punctuation character
-	line = reRunOfBackslashes.ReplaceAll(line, []byte{'\\'})
-	line = reRunOfComma.ReplaceAll(line, []byte{','})
-
-	// tweak the fleet movement to remove the trailing comma from the observations
-	line = bytes.ReplaceAll(line, []byte{',', ')'}, []byte{')'})
-
-	// remove all trailing backslashes from the line
-	line = bytes.TrimRight(line, "\\")
-
+	line, _ = ApplyRules(0, line, FleetMovementRules, nil)
 	return line
 }
 
@@ -59,76 +45,14 @@ func FleetMovement(line []byte) []byte {
 // or direction followed by a unit ID. Caller must have already compressed spaces
 // on the input line and forced to lowercase.
 func ScoutMovement(line []byte) []byte {
-	// replace backslash+dash with backslash
-	line = reBackslashDash.ReplaceAll(line, []byte{'\\'})
-
-	// replace backslash+comma and comma+backslash with backslash
-	line = reBackslashComma.ReplaceAll(line, []byte{'\\'})
-	line = reCommaBackslash.ReplaceAll(line, []byte{'\\'})
-
-	// fix issues with backslash or direction followed by a unit ID
-	line = reBackslashUnit.ReplaceAll(line, []byte{',', '$', '1'})
-	line = reDirectionUnit.ReplaceAll(line, []byte{'$', '1', ',', '$', '2'})
-
-	// reduce runs of certain punctuation to a single punctuation character
-	line = reRunOfBackslashes.ReplaceAll(line, []byte{'\\'})
-	line = reRunOfComma.ReplaceAll(line, []byte{','})
-
-	// change the separator for nothing of interest
-	if idx := bytes.Index(line, []byte(`,nothing of interest found`)); idx != -1 {
-		line[idx] = '\\'
-	}
-	if idx := bytes.Index(line, []byte(`,patrolled and found `)); idx != -1 {
-		line[idx] = '\\'
-	}
-	// change the separator for some things
-	for _, loc := range reCommaFindQtyItem.FindAllIndex(line, -1) {
-		line[loc[0]] = '\\'
-	}
-	for _, loc := range reCommaNoGroups.FindAllIndex(line, -1) {
-		line[loc[0]] = '\\'
-	}
-
-	// remove all trailing backslashes and commas from the line
-	line = bytes.TrimRight(line, "\\,")
-
-	// cleanup lists of directions and units
-	line = ListOfDirections(line)
-	line = ListOfUnitIDs(line)
-
+	line, _ = ApplyRules(0, line, ScoutMovementRules, nil)
 	return line
 }
 
 // TribeMovement processes a tribe movement line to fix issues with backslash or direction followed by a unit ID.
 // Caller must have already compressed spaces on the input line and forced to lowercase.
 func TribeMovement(line []byte) []byte {
-	// force a backslash after the word moves
-	if len(line) > 19 && line[19] == ' ' { // 	"tribe movement:move"
-		line[19] = '\\'
-	}
-
-	// replace backslash+dash with backslash
-	line = reBackslashDash.ReplaceAll(line, []byte{'\\'})
-
-	// replace backslash+comma and comma+backslash with backslash
-	line = reBackslashComma.ReplaceAll(line, []byte{'\\'})
-	line = reCommaBackslash.ReplaceAll(line, []byte{'\\'})
-
-	// fix issues with backslash or direction followed by a unit ID
-	line = reBackslashUnit.ReplaceAll(line, []byte{',', '$', '1'})
-	line = reDirectionUnit.ReplaceAll(line, []byte{'$', '1', ',', '$', '2'})
-
-	// reduce runs of certain punctuation to a single punctuation character
-	line = reRunOfBackslashes.ReplaceAll(line, []byte{'\\'})
-	line = reRunOfComma.ReplaceAll(line, []byte{','})
-
-	// remove all trailing backslashes from the line
-	line = bytes.TrimRight(line, "\\")
-
-	// cleanup lists of directions and units
-	line = ListOfDirections(line)
-	line = ListOfUnitIDs(line)
-
+	line, _ = ApplyRules(0, line, TribeMovementRules, nil)
 	return line
 }
 
@@ -136,28 +60,7 @@ func TribeMovement(line []byte) []byte {
 // or direction followed by a unit ID. Caller must have already compressed spaces
 // on the input line and forced to lowercase.
 func UnitStatus(line []byte) []byte {
-	// replace backslash+dash with backslash
-	line = reBackslashDash.ReplaceAll(line, []byte{'\\'})
-
-	// replace backslash+comma and comma+backslash with backslash
-	line = reBackslashComma.ReplaceAll(line, []byte{'\\'})
-	line = reCommaBackslash.ReplaceAll(line, []byte{'\\'})
-
-	// fix issues with backslash or direction followed by a unit ID
-	line = reBackslashUnit.ReplaceAll(line, []byte{',', '$', '1'})
-	line = reDirectionUnit.ReplaceAll(line, []byte{'$', '1', ',', '$', '2'})
-
-	// reduce runs of certain punctuation to a single punctuation character
-	line = reRunOfBackslashes.ReplaceAll(line, []byte{'\\'})
-	line = reRunOfComma.ReplaceAll(line, []byte{','})
-
-	// remove all trailing backslashes from the line
-	line = bytes.TrimRight(line, "\\")
-
-	// cleanup lists of directions and units
-	line = ListOfDirections(line)
-	line = ListOfUnitIDs(line)
-
+	line, _ = ApplyRules(0, line, UnitStatusRules, nil)
 	return line
 }
 
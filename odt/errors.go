@@ -0,0 +1,13 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package odt
+
+// Error defines a constant error
+type Error string
+
+// Error implements the Errors interface
+func (e Error) Error() string { return string(e) }
+
+const (
+	ErrInvalidDocument Error = "invalid document"
+)
@@ -0,0 +1,164 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package odt provides a reader for OpenDocument text documents.
+//
+// An OpenDocument file is a zip file. The text of the document is in
+// content.xml, with each paragraph or heading in its own element.
+package odt
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+)
+
+// https://docs.oasis-open.org/office/OpenDocument/v1.3/os/part3-schema/OpenDocument-v1.3-os-part3-schema.html
+
+const (
+	officeNamespace = "urn:oasis:names:tc:opendocument:xmlns:office:1.0"
+	textNamespace   = "urn:oasis:names:tc:opendocument:xmlns:text:1.0"
+)
+
+// ReadFile loads an OpenDocument file and returns the text as a slice of byte slices.
+// Each slice of bytes represents a paragraph in the original document.
+func ReadFile(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Read(data)
+}
+
+// Read reads an OpenDocument file from a byte slice and returns the contents as a slice of byte slices.
+// Each slice of bytes is a single paragraph from the document.
+// Line breaks inside a paragraph start a new slice, like they would in a text file.
+func Read(data []byte) ([][]byte, error) {
+	r := bytes.NewReader(data)
+	zr, err := zip.NewReader(r, r.Size())
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+
+	const contentName = "content.xml"
+	var contentFile *zip.File
+	for _, f := range zr.File {
+		if f.Name == contentName {
+			contentFile = f
+			break
+		}
+	}
+	if contentFile == nil {
+		return nil, errors.Join(ErrInvalidDocument, errors.New(contentName+" file not found"))
+	}
+	rdr, err := contentFile.Open()
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+	defer rdr.Close()
+	content, err := io.ReadAll(rdr)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+
+	paragraphs, err := contentParagraphs(content)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidDocument, err)
+	}
+	return paragraphs, nil
+}
+
+// contentParagraphs walks the XML and collects the text of each paragraph and heading.
+// Notes and annotations are skipped since they aren't part of the report text.
+func contentParagraphs(content []byte) ([][]byte, error) {
+	var paragraphs [][]byte
+	var para []byte
+	depth := 0 // nesting of paragraphs, since frames and notes may contain paragraphs
+	skip := 0  // nesting of elements whose text we ignore
+	space := false
+	d := xml.NewDecoder(bytes.NewReader(content))
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			if isSkipped(t.Name) {
+				skip++
+				continue
+			} else if skip != 0 || t.Name.Space != textNamespace {
+				continue
+			}
+			switch t.Name.Local {
+			case "p", "h":
+				depth++
+				space = false
+			case "s":
+				n := 1
+				for _, attr := range t.Attr {
+					if attr.Name.Space == textNamespace && attr.Name.Local == "c" {
+						if c, err := strconv.Atoi(attr.Value); err == nil && c > 0 {
+							n = c
+						}
+					}
+				}
+				para, space = append(para, bytes.Repeat([]byte{' '}, n)...), false
+			case "tab":
+				para, space = append(para, '\t'), false
+			case "line-break":
+				paragraphs = append(paragraphs, para)
+				para, space = nil, false
+			}
+		case xml.EndElement:
+			if isSkipped(t.Name) {
+				skip--
+				continue
+			} else if skip != 0 || t.Name.Space != textNamespace {
+				continue
+			}
+			if (t.Name.Local == "p" || t.Name.Local == "h") && depth != 0 {
+				if depth--; depth == 0 {
+					paragraphs = append(paragraphs, para)
+					para = nil
+				}
+			}
+		case xml.CharData:
+			if depth != 0 && skip == 0 {
+				// runs of white space collapse to a single space.
+				// spaces that matter are encoded with text:s and text:tab elements.
+				for _, ch := range t {
+					if ch == ' ' || ch == '\t' || ch == '\r' || ch == '\n' {
+						if space {
+							continue
+						}
+						ch, space = ' ', true
+					} else {
+						space = false
+					}
+					para = append(para, ch)
+				}
+			}
+		}
+	}
+	return paragraphs, nil
+}
+
+// isSkipped returns true for elements whose text is not part of the body.
+func isSkipped(name xml.Name) bool {
+	switch name.Space {
+	case officeNamespace:
+		return name.Local == "annotation"
+	case textNamespace:
+		switch name.Local {
+		case "note", "tracked-changes", "sequence-decls":
+			return true
+		}
+	}
+	return false
+}
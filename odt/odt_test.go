@@ -0,0 +1,72 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package odt_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"github.com/playbymail/tribal/docx"
+	"github.com/playbymail/tribal/odt"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	const content = `<?xml version="1.0" encoding="UTF-8"?>
+<office:document-content xmlns:office="urn:oasis:names:tc:opendocument:xmlns:office:1.0" xmlns:text="urn:oasis:names:tc:opendocument:xmlns:text:1.0">
+  <office:body>
+    <office:text>
+      <text:sequence-decls><text:sequence-decl text:display-outline-level="0" text:name="Table"/></text:sequence-decls>
+      <text:h text:outline-level="1">Tribe 0987, , Current Hex = KP 0608</text:h>
+      <text:p>Current Turn 900-05 (#5),<text:s/><text:span>Summer</text:span>, FINE<text:note><text:note-body><text:p>a footnote</text:p></text:note-body></text:note></text:p>
+      <text:p>Tribe Movement: Move<text:s text:c="2"/>N-PR<text:tab/>Café<text:line-break/>Scout 1:Scout
+        N-GH</text:p>
+      <text:p><office:annotation><text:p>a comment</text:p></office:annotation>Tribe Follows 0987c1</text:p>
+    </office:text>
+  </office:body>
+</office:document-content>`
+	want := []string{
+		"Tribe 0987, , Current Hex = KP 0608",
+		"Current Turn 900-05 (#5), Summer, FINE",
+		"Tribe Movement: Move  N-PR\tCafé",
+		"Scout 1:Scout N-GH",
+		"Tribe Follows 0987c1",
+	}
+
+	data := odtFile(t, content)
+	if got := docx.DetectWordDocType(data); got != docx.Odt {
+		t.Errorf("DetectWordDocType: want %v, got %v", docx.Odt, got)
+	}
+	got, err := odt.Read(data)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	} else if len(got) != len(want) {
+		t.Fatalf("Read: want %d paragraphs, got %d: %q", len(want), len(got), got)
+	}
+	for i := range want {
+		if string(got[i]) != want[i] {
+			t.Errorf("paragraph %d: want %q, got %q", i+1, want[i], got[i])
+		}
+	}
+}
+
+// odtFile returns a zip file laid out like an OpenDocument text document.
+func odtFile(t *testing.T, content string) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	zw := zip.NewWriter(buf)
+	w, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		t.Fatal(err)
+	} else if _, err = w.Write([]byte("application/vnd.oasis.opendocument.text")); err != nil {
+		t.Fatal(err)
+	}
+	if w, err = zw.Create("content.xml"); err != nil {
+		t.Fatal(err)
+	} else if _, err = w.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err = zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
@@ -18,12 +18,13 @@ type ClanId_t string
 // Unit_t defines a single section of a turn report
 type Unit_t struct {
 	Id          UnitId_t      `json:"id"`
-	Name        UnitName_t    `json:"name,omitempty"`   // optional name field
-	PreviousHex Coordinates_t `json:"previous_hex"`     // location of unit at the beginning of the turn
-	CurrentHex  Coordinates_t `json:"current_hex"`      // location of unit at the end of the turn
-	Turn        *Turn_t       `json:"turn,omitempty"`   // turn number
-	Moves       *Moves_t      `json:"moves,omitempty"`  // moves made by the unit
-	Status      *Status_t     `json:"status,omitempty"` // status of the unit
+	Name        UnitName_t    `json:"name,omitempty"`      // optional name field
+	PreviousHex Coordinates_t `json:"previous_hex"`        // location of unit at the beginning of the turn
+	CurrentHex  Coordinates_t `json:"current_hex"`         // location of unit at the end of the turn
+	Turn        *Turn_t       `json:"turn,omitempty"`      // turn number
+	Moves       *Moves_t      `json:"moves,omitempty"`     // moves made by the unit
+	Status      *Status_t     `json:"status,omitempty"`    // status of the unit
+	Inventory   *Inventory_t  `json:"inventory,omitempty"` // people and goods at the end of the turn
 	Errors      []error       `json:"errors,omitempty"`
 }
 
@@ -38,10 +39,15 @@ type TurnId_t int
 
 // Turn_t defines the turn year and month from a turn report.
 type Turn_t struct {
-	Id    TurnId_t `json:"id"`
-	Year  int      `json:"year"`
-	Month int      `json:"month"`
-	Error error    `json:"error,omitempty"`
+	Id      TurnId_t `json:"id"`
+	Year    int      `json:"year"`
+	Month   int      `json:"month"`
+	Season  string   `json:"season,omitempty"`  // optional, e.g. "summer"
+	Weather string   `json:"weather,omitempty"` // optional, e.g. "fine"
+	// Next and ReportDate are only in the clan's section.
+	Next       *Turn_t `json:"next,omitempty"`        // optional, the turn that orders are due for
+	ReportDate string  `json:"report_date,omitempty"` // optional, as written by the GM, e.g. "14/01/2024"
+	Error      error   `json:"error,omitempty"`
 }
 
 // Moves_t defines a node containing a unit's movement and results in a turn report.
@@ -55,7 +61,7 @@ type Moves_t struct {
 
 // Follows_t defines the results for a follows line
 type Follows_t struct {
-	Turn    *Turn_t       `json:turn`
+	Turn    *Turn_t       `json:"turn"`
 	Id      UnitId_t      `json:"id"`
 	Follows UnitId_t      `json:"follows"`
 	From    Coordinates_t `json:"from,omitempty"`
@@ -83,6 +89,8 @@ type March_t struct {
 	Borders   []*Border_t           `json:"borders,omitempty"`
 	Passages  []*Passage_t          `json:"passages,omitempty"`
 	HexName   *HexName_t            `json:"hex_name,omitempty"`
+	Failure   MoveFailure_e         `json:"failure,omitempty"` // set only if the step failed
+	Line      int                   `json:"line,omitempty"`    // line in the report, 0 if unknown
 	Errors    *MarchErrors_t        `json:"errors,omitempty"`
 }
 
@@ -102,6 +110,8 @@ type Patrol_t struct {
 	Encounters []UnitId_t            `json:"encounters,omitempty"`
 	Items      []Item_t              `json:"items,omitempty"`
 	HexName    *HexName_t            `json:"hex_name,omitempty"`
+	Failure    MoveFailure_e         `json:"failure,omitempty"` // set only if the step failed
+	Line       int                   `json:"line,omitempty"`    // line in the report, 0 if unknown
 	Errors     *PatrolErrors_t       `json:"errors,omitempty"`
 }
 
@@ -151,6 +161,7 @@ type Status_t struct {
 	Turn   *Turn_t         `json:"turn"`
 	Unit   UnitId_t        `json:"unit,omitempty"`
 	Tile   Tile_t          `json:"tile,omitempty"`
+	Line   int             `json:"line,omitempty"` // line in the report, 0 if unknown
 	Errors *StatusErrors_t `json:"errors,omitempty"`
 }
 
@@ -159,6 +170,29 @@ type StatusErrors_t struct {
 	Errors      []error  `json:"errors,omitempty"`
 }
 
+// Inventory_t defines the people and goods blocks of a unit in a turn report.
+// Items that are listed more than once are combined into a single entry.
+type Inventory_t struct {
+	Turn       *Turn_t            `json:"turn"`
+	Unit       UnitId_t           `json:"unit,omitempty"`
+	Population *Population_t      `json:"population,omitempty"`
+	Items      []Item_t           `json:"items,omitempty"`
+	Errors     *InventoryErrors_t `json:"errors,omitempty"`
+}
+
+// Population_t defines the people in a unit.
+type Population_t struct {
+	People    int `json:"people,omitempty"`
+	Warriors  int `json:"warriors,omitempty"`
+	Actives   int `json:"actives,omitempty"`
+	Inactives int `json:"inactives,omitempty"`
+}
+
+type InventoryErrors_t struct {
+	ExcessInput []string `json:"excess_input,omitempty"`
+	Errors      []error  `json:"errors,omitempty"`
+}
+
 type Tile_t struct {
 	Coordinates Coordinates_t         `json:"coordinates"`
 	Terrain     terrain.Terrain_e     `json:"terrain,omitempty"`
@@ -41,9 +41,15 @@ func (c Coordinates_t) String() string {
 func TextToCoordinates(text []byte) (Coordinates_t, error) {
 	if bytes.Equal(text, []byte{'n', '/', 'a'}) {
 		return Coordinates_t{}, nil
-	} else if len(text) != 7 {
+	} else if len(text) != 7 || text[2] != ' ' {
 		return Coordinates_t{}, domains.ErrInvalidCoordinates
 	}
+	// column and row must be digits
+	for _, ch := range text[3:] {
+		if !('0' <= ch && ch <= '9') {
+			return Coordinates_t{}, domains.ErrInvalidCoordinates
+		}
+	}
 	c := Coordinates_t{
 		GridRow:    int(text[0]-'a') + 1,
 		GridColumn: int(text[1]-'a') + 1,
@@ -75,9 +81,25 @@ func (c Coordinates_t) MarshalText() ([]byte, error) {
 	return []byte(c.String()), nil
 }
 
-//func (c *Coordinates_t) UnmarshalJSON(data []byte) error {
-//	return json.Unmarshal(data, &m.Field)
-//}
+// UnmarshalJSON implements the json.Unmarshaler interface.
+// It accepts the output of MarshalJSON, e.g. "KP 0608", "## 0608" or "n/a".
+func (c *Coordinates_t) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return c.UnmarshalText([]byte(s))
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (c *Coordinates_t) UnmarshalText(text []byte) error {
+	coords, err := TextToCoordinates(bytes.ToLower(text))
+	if err != nil {
+		return fmt.Errorf("invalid Coordinates %q: %w", text, err)
+	}
+	*c = coords
+	return nil
+}
 
 func (c Coordinates_t) Move(d direction.Direction_e) Coordinates_t {
 	if d == direction.None {
@@ -0,0 +1,29 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package ast_test
+
+import (
+	"github.com/playbymail/tribal/parser/ast"
+	"testing"
+)
+
+func TestTextToCoordinates(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		ok    bool
+	}{
+		{"kp 0608", true},
+		{"n/a", true},
+		{"##00101", false}, // no space between the grid and the column
+		{"kp06 08", false},
+		{"kp 06a8", false},
+		{"kp 0608 ", false},
+	} {
+		_, err := ast.TextToCoordinates([]byte(tc.input))
+		if tc.ok && err != nil {
+			t.Errorf("%q: want ok, got %v", tc.input, err)
+		} else if !tc.ok && err == nil {
+			t.Errorf("%q: want error, got ok", tc.input)
+		}
+	}
+}
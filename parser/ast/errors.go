@@ -3,6 +3,7 @@
 package ast
 
 const (
+	ErrCurrentHexMismatch    Error = "current hex mismatch"
 	ErrInvalidCoordinates    Error = "invalid coordinates"
 	ErrInvalidMonth          Error = "invalid month"
 	ErrInvalidTurnNo         Error = "invalid turn number"
@@ -16,10 +17,13 @@ const (
 	ErrNotScoutPatrolLine    Error = "not a scout patrol line"
 	ErrNotTribeMovementLine  Error = "not a tribe movement line"
 	ErrNotUnitStatusLine     Error = "not a unit status line"
+	ErrStatusTerrainMismatch Error = "status terrain mismatch"
+	ErrStatusUnitMismatch    Error = "status unit mismatch"
 	ErrTooFewFields          Error = "too few fields"
 	ErrTooManyFields         Error = "too many fields"
 	ErrTurnNoMismatch        Error = "turn number mismatch"
 	ErrUnexpectedInput       Error = "unexpected input"
+	ErrUnitNotEncountered    Error = "unit not in encounters"
 )
 
 // Error defines a constant error
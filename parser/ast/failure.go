@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package ast
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MoveFailure_e is an enum for the reasons that a step of a march or patrol failed.
+// The failed step doesn't leave the hex; the reason tells us something about the
+// neighbor in the direction that the unit tried to move.
+type MoveFailure_e int
+
+const (
+	NoFailure       MoveFailure_e = iota
+	CantMoveOnWater               // "can't move on ocean to n of hex"
+	CantMoveWagons                // "cannot move wagons into swamp/jungle hill to n of hex"
+	NoFord                        // "no ford on river to se of hex"
+	NotEnoughMPs                  // "not enough m.p's to move to sw into grassy hills"
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e MoveFailure_e) MarshalJSON() ([]byte, error) {
+	return json.Marshal(MoveFailureToString[e])
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *MoveFailure_e) UnmarshalJSON(data []byte) error {
+	var s string
+	var ok bool
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	} else if *e, ok = StringToMoveFailure[s]; !ok {
+		return fmt.Errorf("invalid MoveFailure %q", s)
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (e MoveFailure_e) String() string {
+	if str, ok := MoveFailureToString[e]; ok {
+		return str
+	}
+	return fmt.Sprintf("MoveFailure(%d)", int(e))
+}
+
+var (
+	// MoveFailureToString is a helper map for marshalling the enum.
+	// The strings are the values stored in the moves.failure_reason column.
+	MoveFailureToString = map[MoveFailure_e]string{
+		NoFailure:       "",
+		CantMoveOnWater: "CANT_MOVE_ON_WATER",
+		CantMoveWagons:  "CANT_MOVE_WAGONS",
+		NoFord:          "NO_FORD",
+		NotEnoughMPs:    "NOT_ENOUGH_MPS",
+	}
+	// StringToMoveFailure is a helper map for unmarshalling the enum
+	StringToMoveFailure = map[string]MoveFailure_e{
+		"":                   NoFailure,
+		"CANT_MOVE_ON_WATER": CantMoveOnWater,
+		"CANT_MOVE_WAGONS":   CantMoveWagons,
+		"NO_FORD":            NoFord,
+		"NOT_ENOUGH_MPS":     NotEnoughMPs,
+	}
+)
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package ast_test
+
+import (
+	"bytes"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+	"testing"
+)
+
+func FuzzTextToCoordinates(f *testing.F) {
+	for _, seed := range []string{"kp 0608", "## 0608", "n/a", "aa 0101", "zz 3021", "ab 3100", "kp06 08"} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, input []byte) {
+		c, err := ast.TextToCoordinates(input)
+		if err != nil {
+			return
+		}
+		// valid coordinates must round-trip through the string representation
+		if got := bytes.ToLower([]byte(c.String())); !bytes.Equal(got, input) {
+			t.Errorf("coordinates: %q: round-trip %q", input, got)
+		}
+		// every neighbor must be reachable and must lead back to the origin
+		for _, d := range direction.Directions {
+			to := c.Move(d)
+			if c.IsZero() {
+				if !to.IsZero() {
+					t.Errorf("coordinates: %q: %s: want n/a, got %s", input, d, to)
+				}
+				continue
+			}
+			if !(1 <= to.Column && to.Column <= 30 && 1 <= to.Row && to.Row <= 21) {
+				t.Errorf("coordinates: %q: %s: out of bounds %s", input, d, to)
+			}
+		}
+	})
+}
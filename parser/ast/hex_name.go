@@ -2,7 +2,10 @@
 
 package ast
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"fmt"
+)
 
 // HexName_t is a special hex name or a village name.
 // We don't actually know how to distinguish between the two in the parser,
@@ -32,3 +35,23 @@ func (e HexName_e) MarshalText() ([]byte, error) {
 	}
 	return []byte("Special Hex"), nil
 }
+
+func (e *HexName_e) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return e.UnmarshalText([]byte(s))
+}
+
+func (e *HexName_e) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "Village":
+		*e = VillageName
+	case "Special Hex":
+		*e = SpecialHex
+	default:
+		return fmt.Errorf("invalid HexName %q", text)
+	}
+	return nil
+}
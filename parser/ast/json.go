@@ -0,0 +1,231 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package ast
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// The json package can't unmarshal into an error interface, so every type
+// with an error field implements the json.Marshaler and json.Unmarshaler
+// interfaces. The errors are written as Error_t objects and read back as
+// *Error_t values. Each method uses an alias of the type (which has no
+// methods) and shadows the error field with an Error_t field of the same
+// name, so the other fields keep their usual encoding.
+
+// Error_t is an error that survives a round trip through JSON.
+type Error_t struct {
+	// Kind is the text of the innermost wrapped error, which is usually
+	// a sentinel error like ErrNoMatch. It is empty if nothing was wrapped.
+	Kind    string `json:"kind,omitempty"`
+	Message string `json:"message"`
+}
+
+// NewError returns the JSON form of the error, or nil if there is no error.
+func NewError(err error) *Error_t {
+	if err == nil {
+		return nil
+	} else if e, ok := err.(*Error_t); ok {
+		return e
+	}
+	e := &Error_t{Message: err.Error()}
+	for inner := errors.Unwrap(err); inner != nil; inner = errors.Unwrap(inner) {
+		e.Kind = inner.Error()
+	}
+	return e
+}
+
+// Error implements the error interface.
+func (e *Error_t) Error() string {
+	return e.Message
+}
+
+// Is lets errors.Is match the sentinel error that was wrapped before the
+// error was written. Sentinel errors are compared by their text.
+func (e *Error_t) Is(target error) bool {
+	if e.Kind != "" {
+		return target.Error() == e.Kind
+	}
+	return target.Error() == e.Message
+}
+
+func newErrors(list []error) []*Error_t {
+	var errs []*Error_t
+	for _, err := range list {
+		errs = append(errs, NewError(err))
+	}
+	return errs
+}
+
+func fromErrors(list []*Error_t) []error {
+	var errs []error
+	for _, err := range list {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (u Unit_t) MarshalJSON() ([]byte, error) {
+	type alias Unit_t
+	return json.Marshal(struct {
+		alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: alias(u), Errors: newErrors(u.Errors)})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (u *Unit_t) UnmarshalJSON(data []byte) error {
+	type alias Unit_t
+	aux := struct {
+		*alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: (*alias)(u)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	u.Errors = fromErrors(aux.Errors)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (t Turn_t) MarshalJSON() ([]byte, error) {
+	type alias Turn_t
+	return json.Marshal(struct {
+		alias
+		Error *Error_t `json:"error,omitempty"`
+	}{alias: alias(t), Error: NewError(t.Error)})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (t *Turn_t) UnmarshalJSON(data []byte) error {
+	type alias Turn_t
+	aux := struct {
+		*alias
+		Error *Error_t `json:"error,omitempty"`
+	}{alias: (*alias)(t)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	t.Error = nil
+	if aux.Error != nil {
+		t.Error = aux.Error
+	}
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (m Moves_t) MarshalJSON() ([]byte, error) {
+	type alias Moves_t
+	return json.Marshal(struct {
+		alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: alias(m), Errors: newErrors(m.Errors)})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (m *Moves_t) UnmarshalJSON(data []byte) error {
+	type alias Moves_t
+	aux := struct {
+		*alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: (*alias)(m)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	m.Errors = fromErrors(aux.Errors)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e MarchErrors_t) MarshalJSON() ([]byte, error) {
+	type alias MarchErrors_t
+	return json.Marshal(struct {
+		alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: alias(e), Errors: newErrors(e.Errors)})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *MarchErrors_t) UnmarshalJSON(data []byte) error {
+	type alias MarchErrors_t
+	aux := struct {
+		*alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: (*alias)(e)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	e.Errors = fromErrors(aux.Errors)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e PatrolErrors_t) MarshalJSON() ([]byte, error) {
+	type alias PatrolErrors_t
+	return json.Marshal(struct {
+		alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: alias(e), Errors: newErrors(e.Errors)})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *PatrolErrors_t) UnmarshalJSON(data []byte) error {
+	type alias PatrolErrors_t
+	aux := struct {
+		*alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: (*alias)(e)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	e.Errors = fromErrors(aux.Errors)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e StatusErrors_t) MarshalJSON() ([]byte, error) {
+	type alias StatusErrors_t
+	return json.Marshal(struct {
+		alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: alias(e), Errors: newErrors(e.Errors)})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *StatusErrors_t) UnmarshalJSON(data []byte) error {
+	type alias StatusErrors_t
+	aux := struct {
+		*alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: (*alias)(e)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	e.Errors = fromErrors(aux.Errors)
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e InventoryErrors_t) MarshalJSON() ([]byte, error) {
+	type alias InventoryErrors_t
+	return json.Marshal(struct {
+		alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: alias(e), Errors: newErrors(e.Errors)})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *InventoryErrors_t) UnmarshalJSON(data []byte) error {
+	type alias InventoryErrors_t
+	aux := struct {
+		*alias
+		Errors []*Error_t `json:"errors,omitempty"`
+	}{alias: (*alias)(e)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	e.Errors = fromErrors(aux.Errors)
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package ast_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestJSONRoundTrip verifies that the golden files for the section parser
+// are unchanged after unmarshalling and marshalling them again.
+func TestJSONRoundTrip(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("..", "..", "section", "testdata", "*.golden.json"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) == 0 {
+		t.Fatal("section/testdata: no golden files found")
+	}
+	for _, path := range paths {
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var units []*ast.Unit_t
+		if err := json.Unmarshal(want, &units); err != nil {
+			t.Fatalf("%s: unmarshal: %v", path, err)
+		}
+		got, err := json.MarshalIndent(units, "", "  ")
+		if err != nil {
+			t.Fatalf("%s: marshal: %v", path, err)
+		}
+		if got = append(got, '\n'); !bytes.Equal(got, want) {
+			t.Errorf("%s: round trip does not match", path)
+		}
+	}
+}
+
+func TestJSONErrors(t *testing.T) {
+	unit := &ast.Unit_t{
+		Id:     "0987",
+		Turn:   &ast.Turn_t{Id: 5, Year: 900, Month: 5, Error: ast.ErrTurnNoMismatch},
+		Moves:  &ast.Moves_t{Errors: []error{fmt.Errorf("line 3: %w", ast.ErrNoMatch)}},
+		Errors: []error{errors.New("plain error")},
+	}
+	data, err := json.Marshal(unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got ast.Unit_t
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !errors.Is(got.Turn.Error, ast.ErrTurnNoMismatch) {
+		t.Errorf("turn: want %v, got %v", ast.ErrTurnNoMismatch, got.Turn.Error)
+	}
+	if len(got.Moves.Errors) != 1 || !errors.Is(got.Moves.Errors[0], ast.ErrNoMatch) || got.Moves.Errors[0].Error() != "line 3: no match" {
+		t.Errorf("moves: want %q, got %v", "line 3: no match", got.Moves.Errors)
+	}
+	if len(got.Errors) != 1 || got.Errors[0].Error() != "plain error" {
+		t.Errorf("unit: want %q, got %v", "plain error", got.Errors)
+	}
+	if again, err := json.Marshal(&got); err != nil {
+		t.Fatal(err)
+	} else if !bytes.Equal(again, data) {
+		t.Errorf("round trip:\n\twant %s\n\t got %s", data, again)
+	}
+}
+
+func TestJSONMapKeys(t *testing.T) {
+	want := map[direction.Direction_e]ast.Coordinates_t{
+		direction.North: {GridRow: 11, GridColumn: 16, Column: 6, Row: 7},
+		direction.South: {Column: 6, Row: 9},
+	}
+	data, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[direction.Direction_e]ast.Coordinates_t
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(want) || got[direction.North] != want[direction.North] || got[direction.South] != want[direction.South] {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package ast
+
+import (
+	"bytes"
+	"strconv"
+)
+
+// TextToTurnId converts text like "901-03" to a turn id.
+// The year 899 only has the 12th month, which is turn 0.
+func TextToTurnId(text []byte) (TurnId_t, error) {
+	yy, mm, ok := bytes.Cut(bytes.TrimSpace(text), []byte{'-'})
+	if !ok {
+		return 0, ErrInvalidTurnNo
+	}
+	year, err := strconv.Atoi(string(yy))
+	if err != nil || !(899 <= year && year <= 9999) {
+		return 0, ErrInvalidYear
+	}
+	month, err := strconv.Atoi(string(mm))
+	if err != nil || !(1 <= month && month <= 12) || (year == 899 && month != 12) {
+		return 0, ErrInvalidMonth
+	}
+	return TurnId_t((year-899)*12 + month - 12), nil
+}
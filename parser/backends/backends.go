@@ -0,0 +1,66 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package backends adapts each of the report parsers to a single interface
+// so that we can run them side by side and compare the results.
+//
+// Most of the backends are experiments that never got past the unit header.
+// The comparison tells us which ones are safe to retire and which one to trust.
+package backends
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"sort"
+)
+
+// ReportParser is the interface implemented by every parser backend.
+type ReportParser interface {
+	// Name returns the short name of the backend.
+	Name() string
+	// Parse parses the report and returns the units in the order they
+	// appear in the report. Input is the text of the report; callers
+	// must extract the text from Word documents before calling Parse.
+	// Errors that don't stop the parse are returned as diagnostics.
+	Parse(path string, input []byte) ([]*ast.Unit_t, []*Diagnostic_t, error)
+}
+
+// Diagnostic_t is a problem found by a backend while parsing a report.
+type Diagnostic_t struct {
+	Backend string
+	Line    int          // line number in the input, zero if not known
+	Unit    ast.UnitId_t // unit being parsed, empty if not known
+	Message string
+}
+
+func (d *Diagnostic_t) String() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s: %s", d.Backend, d.Unit, d.Message)
+	}
+	return fmt.Sprintf("%s: %d: %s: %s", d.Backend, d.Line, d.Unit, d.Message)
+}
+
+// All returns all the backends, sorted by name.
+func All() []ReportParser {
+	list := []ReportParser{
+		&lemonBackend{},
+		&parserBackend{},
+		&pigeonBackend{},
+		&rdpBackend{},
+		&scannerBackend{},
+		&sectionBackend{},
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name() < list[j].Name()
+	})
+	return list
+}
+
+// Lookup returns the backend with the given name.
+func Lookup(name string) (ReportParser, bool) {
+	for _, b := range All() {
+		if b.Name() == name {
+			return b, true
+		}
+	}
+	return nil, false
+}
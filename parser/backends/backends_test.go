@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backends_test
+
+import (
+	"github.com/playbymail/tribal/parser/backends"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// every backend should find the same units in the section corpus,
+// and agree with the section backend on the values they both populate.
+func TestBackendsAgree(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	paths, err := filepath.Glob(filepath.Join("..", "..", "section", "testdata", "*.report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) == 0 {
+		t.Fatal("testdata: no reports found")
+	}
+	baseline, ok := backends.Lookup("section")
+	if !ok {
+		t.Fatal("section: backend not registered")
+	}
+	for _, path := range paths {
+		input, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := backends.Run(path, input, baseline)[0]
+		if want.Error != nil {
+			t.Fatalf("%s: section: %v", path, want.Error)
+		}
+		for _, b := range backends.All() {
+			got := backends.Run(path, input, b)[0]
+			if got.Error != nil {
+				t.Errorf("%s: %s: %v", path, b.Name(), got.Error)
+				continue
+			}
+			diffs, err := backends.Compare(want, got, false)
+			if err != nil {
+				t.Fatalf("%s: %s: %v", path, b.Name(), err)
+			}
+			for _, d := range diffs {
+				t.Errorf("%s: %s", path, d)
+			}
+		}
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backends
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"sort"
+	"strings"
+)
+
+// Result_t is the output of a single backend.
+type Result_t struct {
+	Backend     string
+	Units       []*ast.Unit_t
+	Diagnostics []*Diagnostic_t
+	Error       error
+}
+
+// Difference_t is a single difference between two backends.
+// Path is the JSON path of the value within the unit, or empty
+// if the unit is missing from one of the backends.
+type Difference_t struct {
+	Unit  ast.UnitId_t
+	Path  string
+	Left  Side_t
+	Right Side_t
+}
+
+// Side_t is one side of a difference.
+type Side_t struct {
+	Backend string
+	Value   string
+	Missing bool
+}
+
+func (d *Difference_t) String() string {
+	left, right := d.Left.Value, d.Right.Value
+	if d.Left.Missing {
+		left = "(missing)"
+	}
+	if d.Right.Missing {
+		right = "(missing)"
+	}
+	if d.Path == "" {
+		return fmt.Sprintf("%s: %s %s, %s %s", d.Unit, d.Left.Backend, left, d.Right.Backend, right)
+	}
+	return fmt.Sprintf("%s: %s: %s %s, %s %s", d.Unit, d.Path, d.Left.Backend, left, d.Right.Backend, right)
+}
+
+// Run runs every backend against the input and returns the results
+// in the same order as the backends.
+func Run(path string, input []byte, list ...ReportParser) []*Result_t {
+	var results []*Result_t
+	for _, b := range list {
+		units, diagnostics, err := b.Parse(path, input)
+		results = append(results, &Result_t{Backend: b.Name(), Units: units, Diagnostics: diagnostics, Error: err})
+	}
+	return results
+}
+
+// Compare returns the differences between two results.
+//
+// Most backends only populate part of the unit, so by default we only
+// report values that both backends populated. If all is true, we also
+// report values that are missing from one of the backends.
+func Compare(left, right *Result_t, all bool) ([]*Difference_t, error) {
+	lu, lorder, err := flattenUnits(left.Units)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", left.Backend, err)
+	}
+	ru, rorder, err := flattenUnits(right.Units)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", right.Backend, err)
+	}
+
+	// report units in the order they appear in the left backend,
+	// followed by units that are only in the right backend.
+	order := lorder
+	for _, id := range rorder {
+		if _, ok := lu[id]; !ok {
+			order = append(order, id)
+		}
+	}
+
+	var diffs []*Difference_t
+	for _, id := range order {
+		lv, lok := lu[id]
+		rv, rok := ru[id]
+		if !lok || !rok {
+			diffs = append(diffs, &Difference_t{
+				Unit:  id,
+				Left:  Side_t{Backend: left.Backend, Value: "(present)", Missing: !lok},
+				Right: Side_t{Backend: right.Backend, Value: "(present)", Missing: !rok},
+			})
+			continue
+		}
+		var paths []string
+		for k := range lv {
+			paths = append(paths, k)
+		}
+		for k := range rv {
+			if _, ok := lv[k]; !ok {
+				paths = append(paths, k)
+			}
+		}
+		sort.Strings(paths)
+		for _, k := range paths {
+			l, lok := lv[k]
+			r, rok := rv[k]
+			if lok && rok && l == r {
+				continue
+			} else if !(lok && rok) && !all {
+				continue
+			}
+			diffs = append(diffs, &Difference_t{
+				Unit:  id,
+				Path:  k,
+				Left:  Side_t{Backend: left.Backend, Value: l, Missing: !lok},
+				Right: Side_t{Backend: right.Backend, Value: r, Missing: !rok},
+			})
+		}
+	}
+	return diffs, nil
+}
+
+// flattenUnits converts each unit to a map of JSON path to value.
+// Units are keyed by id. If a backend returns the same unit more than
+// once, the later copies are keyed as "id#2", "id#3" and so on.
+func flattenUnits(units []*ast.Unit_t) (map[ast.UnitId_t]map[string]string, []ast.UnitId_t, error) {
+	flat := map[ast.UnitId_t]map[string]string{}
+	var order []ast.UnitId_t
+	for _, u := range units {
+		if u == nil {
+			continue
+		}
+		buf, err := json.Marshal(u)
+		if err != nil {
+			return nil, nil, err
+		}
+		var v any
+		if err := json.Unmarshal(buf, &v); err != nil {
+			return nil, nil, err
+		}
+		id := u.Id
+		for n := 2; ; n++ {
+			if _, ok := flat[id]; !ok {
+				break
+			}
+			id = ast.UnitId_t(fmt.Sprintf("%s#%d", u.Id, n))
+		}
+		values := map[string]string{}
+		flatten("", v, values)
+		flat[id] = values
+		order = append(order, id)
+	}
+	return flat, order, nil
+}
+
+// flatten walks a decoded JSON value and records every leaf.
+// Null values and empty objects are treated as missing, as is "n/a",
+// which is how the zero value of Coordinates_t is marshaled.
+func flatten(prefix string, v any, values map[string]string) {
+	switch v := v.(type) {
+	case nil:
+	case string:
+		if v != "n/a" {
+			buf, _ := json.Marshal(v)
+			values[prefix] = string(buf)
+		}
+	case map[string]any:
+		for k, e := range v {
+			flatten(strings.TrimPrefix(prefix+"."+k, "."), e, values)
+		}
+	case []any:
+		for i, e := range v {
+			flatten(fmt.Sprintf("%s[%d]", prefix, i), e, values)
+		}
+	default:
+		buf, _ := json.Marshal(v)
+		values[prefix] = string(buf)
+	}
+}
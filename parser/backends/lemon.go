@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backends
+
+import (
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/parser/lemon"
+	"github.com/playbymail/tribal/section"
+)
+
+// lemonBackend adapts the parser in parser/lemon.
+// It runs on the sections from the splitter, but the parser itself
+// isn't implemented, so it only returns the unit ids from the splitter.
+type lemonBackend struct{}
+
+func (b *lemonBackend) Name() string {
+	return "lemon"
+}
+
+func (b *lemonBackend) Parse(path string, input []byte) (units []*ast.Unit_t, diagnostics []*Diagnostic_t, err error) {
+	for _, s := range section.New(section.DefaultConfig()).Split(input) {
+		unit := &ast.Unit_t{Id: ast.UnitId_t(s.UnitId)}
+		units = append(units, unit)
+		node, err := lemon.ParseAlloc(s).Parse()
+		if err != nil {
+			diagnostics = append(diagnostics, &Diagnostic_t{Backend: b.Name(), Line: s.Line, Unit: unit.Id, Message: err.Error()})
+		} else if node.Error != nil {
+			diagnostics = append(diagnostics, &Diagnostic_t{Backend: b.Name(), Line: s.Line, Unit: unit.Id, Message: node.Error.Error()})
+		}
+	}
+	return units, diagnostics, nil
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backends
+
+import (
+	"github.com/playbymail/tribal/parser"
+	"github.com/playbymail/tribal/parser/ast"
+)
+
+// parserBackend adapts the pigeon parser generated from parser/grammar.peg.
+// It only extracts unit ids and the turn number.
+type parserBackend struct{}
+
+func (b *parserBackend) Name() string {
+	return "parser"
+}
+
+func (b *parserBackend) Parse(path string, input []byte) ([]*ast.Unit_t, []*Diagnostic_t, error) {
+	rpt, err := parser.Report(path, parser.WithData(input))
+	if rpt == nil {
+		return nil, nil, err
+	}
+	var diagnostics []*Diagnostic_t
+	if err != nil {
+		diagnostics = append(diagnostics, &Diagnostic_t{Backend: b.Name(), Message: err.Error()})
+	}
+	var units []*ast.Unit_t
+	for _, u := range rpt.Units {
+		unit := &ast.Unit_t{Id: ast.UnitId_t(u.Id)}
+		if turn := u.Turn; turn != nil {
+			unit.Turn = &ast.Turn_t{Id: ast.TurnId_t(turn.No), Year: turn.Year, Month: turn.Month, Error: turn.Error}
+		}
+		if u.Error != nil {
+			diagnostics = append(diagnostics, &Diagnostic_t{Backend: b.Name(), Unit: unit.Id, Message: u.Error.Error()})
+		}
+		units = append(units, unit)
+	}
+	return units, diagnostics, nil
+}
@@ -0,0 +1,35 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backends
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/parser/pigeon"
+)
+
+// pigeonBackend adapts the line-oriented parser in parser/pigeon,
+// which uses the grammar registered for the report's layout.
+// It only extracts unit ids.
+type pigeonBackend struct{}
+
+func (b *pigeonBackend) Name() string {
+	return "pigeon"
+}
+
+func (b *pigeonBackend) Parse(path string, input []byte) ([]*ast.Unit_t, []*Diagnostic_t, error) {
+	p, err := pigeon.New(pigeon.WithData(input))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer p.Free()
+	root, err := p.Parse()
+	if err != nil {
+		return nil, nil, err
+	}
+	units, ok := root.([]*ast.Unit_t)
+	if !ok {
+		panic(fmt.Sprintf("assert(%T == []*Unit_t)", root))
+	}
+	return units, nil, nil
+}
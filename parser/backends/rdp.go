@@ -0,0 +1,30 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backends
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/parser/rdp"
+)
+
+// rdpBackend adapts the recursive descent parser in parser/rdp.
+// It only extracts unit ids.
+type rdpBackend struct{}
+
+func (b *rdpBackend) Name() string {
+	return "rdp"
+}
+
+func (b *rdpBackend) Parse(path string, input []byte) (units []*ast.Unit_t, diagnostics []*Diagnostic_t, err error) {
+	// the parser panics when it can't find a section header
+	defer func() {
+		if r := recover(); r != nil {
+			diagnostics = append(diagnostics, &Diagnostic_t{Backend: b.Name(), Message: fmt.Sprintf("panic: %v", r)})
+		}
+	}()
+	for _, s := range rdp.ParseAlloc(input).Parse() {
+		units = append(units, &ast.Unit_t{Id: ast.UnitId_t(s.UnitId)})
+	}
+	return units, diagnostics, nil
+}
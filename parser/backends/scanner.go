@@ -0,0 +1,61 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backends
+
+import (
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/parser/scanner"
+)
+
+// scannerBackend adapts the token scanner in parser/scanner.
+// There's no parser on top of the scanner, so we look for unit
+// declarations at the start of each line. It only extracts unit ids.
+type scannerBackend struct{}
+
+func (b *scannerBackend) Name() string {
+	return "scanner"
+}
+
+func (b *scannerBackend) Parse(path string, input []byte) ([]*ast.Unit_t, []*Diagnostic_t, error) {
+	s, err := scanner.NewFromReport(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	var units []*ast.Unit_t
+	var diagnostics []*Diagnostic_t
+	line, startOfLine := 1, true
+	for t := s.Next(); t.Type != scanner.EOF; t = s.Next() {
+		if t.Type == scanner.BOF {
+			continue
+		} else if t.Type == scanner.Newline {
+			line, startOfLine = line+1, true
+			continue
+		} else if !startOfLine {
+			continue
+		}
+		startOfLine = false
+		switch t.Type {
+		case scanner.Courier, scanner.Element, scanner.Fleet, scanner.Garrison, scanner.Tribe:
+		default:
+			continue
+		}
+		s.Skip(scanner.Whitespace)
+		id := s.NextWithState("unit-id")
+		switch id.Type {
+		case scanner.CourierID, scanner.ElementID, scanner.FleetID, scanner.GarrisonID, scanner.TribeID:
+		default:
+			// "tribe movement" and "tribe follows" start with the same keyword
+			if id.Type == scanner.Newline {
+				line++
+				startOfLine = true
+			}
+			continue
+		}
+		if _, ok := s.Accept(scanner.Comma); !ok {
+			diagnostics = append(diagnostics, &Diagnostic_t{Backend: b.Name(), Line: line, Unit: ast.UnitId_t(id.Value), Message: "unit id not followed by comma"})
+			continue
+		}
+		units = append(units, &ast.Unit_t{Id: ast.UnitId_t(id.Value)})
+	}
+	return units, diagnostics, nil
+}
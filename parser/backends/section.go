@@ -0,0 +1,34 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package backends
+
+import (
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/section"
+)
+
+// sectionBackend adapts the section splitter and the common parsers.
+// This is the backend that ottomap uses to build the map.
+type sectionBackend struct{}
+
+func (b *sectionBackend) Name() string {
+	return "section"
+}
+
+func (b *sectionBackend) Parse(path string, input []byte) (units []*ast.Unit_t, diagnostics []*Diagnostic_t, err error) {
+	for _, s := range section.New(section.DefaultConfig()).Parse(path, input) {
+		if s.Error != nil {
+			diagnostics = append(diagnostics, &Diagnostic_t{Backend: b.Name(), Line: s.Line, Unit: ast.UnitId_t(s.UnitId), Message: s.Error.Error()})
+		}
+		for _, err := range s.Errors {
+			diagnostics = append(diagnostics, &Diagnostic_t{Backend: b.Name(), Line: s.Line, Unit: ast.UnitId_t(s.UnitId), Message: err.Error()})
+		}
+		if s.Unit != nil {
+			for _, err := range s.Unit.Errors {
+				diagnostics = append(diagnostics, &Diagnostic_t{Backend: b.Name(), Line: s.Line, Unit: s.Unit.Id, Message: err.Error()})
+			}
+			units = append(units, s.Unit)
+		}
+	}
+	return units, diagnostics, nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package cache stores parsed turn reports as JSON so that unchanged
+// reports don't have to be parsed again.
+//
+// Entries are keyed by the hash of the report text. Each entry records the
+// version of the application that wrote it, and entries from any other
+// version are ignored, since the parsers may have changed.
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/playbymail/tribal"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/store"
+	"os"
+	"path/filepath"
+)
+
+// Format is the version of the entry layout.
+// Increment it when the JSON form of the AST changes.
+const Format = 6
+
+// Cache_t is a directory of parsed reports.
+type Cache_t struct {
+	path    string
+	version string
+}
+
+// entry_t is the JSON form of a cached report.
+type entry_t struct {
+	Format      int           `json:"format"`
+	Version     string        `json:"version"`
+	Hash        string        `json:"hash"`
+	Diagnostics []string      `json:"diagnostics,omitempty"`
+	Units       []*ast.Unit_t `json:"units"`
+}
+
+// Open returns a cache that uses the directory, creating it if needed.
+func Open(path string) (*Cache_t, error) {
+	if path == "" {
+		return nil, ErrMissingPath
+	} else if err := os.MkdirAll(path, 0755); err != nil {
+		return nil, err
+	}
+	return &Cache_t{path: path, version: tribal.Version().String()}, nil
+}
+
+// Path returns the path of the cache directory.
+func (c *Cache_t) Path() string {
+	return c.path
+}
+
+// Load returns the units and diagnostics for the report text.
+// Returns false if the report isn't in the cache or the entry is stale.
+func (c *Cache_t) Load(input []byte) ([]*ast.Unit_t, []string, bool) {
+	hash := store.Hash(input)
+	data, err := os.ReadFile(c.entryPath(hash))
+	if err != nil {
+		return nil, nil, false
+	}
+	var e entry_t
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, nil, false
+	} else if e.Format != Format || e.Version != c.version || e.Hash != hash {
+		return nil, nil, false
+	}
+	return e.Units, e.Diagnostics, true
+}
+
+// Save adds the units and diagnostics for the report text to the cache.
+// The entry is written to a temporary file and renamed, so a reader
+// never sees a partial entry.
+func (c *Cache_t) Save(input []byte, units []*ast.Unit_t, diagnostics []string) error {
+	e := entry_t{
+		Format:      Format,
+		Version:     c.version,
+		Hash:        store.Hash(input),
+		Diagnostics: diagnostics,
+		Units:       units,
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("cache: %s: %w", e.Hash, err)
+	}
+	fd, err := os.CreateTemp(c.path, e.Hash+".*.tmp")
+	if err != nil {
+		return err
+	}
+	_, err = fd.Write(data)
+	if cerr := fd.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(fd.Name(), c.entryPath(e.Hash))
+	}
+	if err != nil {
+		return errors.Join(err, os.Remove(fd.Name()))
+	}
+	return nil
+}
+
+// Clear removes every entry from the cache.
+func (c *Cache_t) Clear() error {
+	paths, err := filepath.Glob(filepath.Join(c.path, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Cache_t) entryPath(hash string) string {
+	return filepath.Join(c.path, hash+".json")
+}
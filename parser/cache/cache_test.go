@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package cache_test
+
+import (
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/parser/cache"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c, err := cache.Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := []byte("Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0608)\n")
+	if _, _, ok := c.Load(input); ok {
+		t.Fatalf("load: empty cache: want miss, got hit")
+	}
+	units := []*ast.Unit_t{{
+		Id:          "0987",
+		PreviousHex: ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8},
+		CurrentHex:  ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8},
+		Turn:        &ast.Turn_t{Id: 5, Year: 900, Month: 5, Error: ast.ErrTurnNoMismatch},
+	}}
+	if err := c.Save(input, units, []string{"line 1: note"}); err != nil {
+		t.Fatal(err)
+	}
+	got, notes, ok := c.Load(input)
+	if !ok {
+		t.Fatalf("load: want hit, got miss")
+	} else if len(got) != 1 || got[0].Id != "0987" || got[0].CurrentHex != units[0].CurrentHex || got[0].Turn.Error.Error() != ast.ErrTurnNoMismatch.Error() {
+		t.Errorf("load: want %+v, got %+v", units[0], got[0])
+	} else if len(notes) != 1 || notes[0] != "line 1: note" {
+		t.Errorf("load: diagnostics: want %q, got %q", "line 1: note", notes)
+	}
+	if _, _, ok := c.Load(append(input, '\n')); ok {
+		t.Errorf("load: changed report: want miss, got hit")
+	}
+	if err := c.Clear(); err != nil {
+		t.Fatal(err)
+	} else if _, _, ok := c.Load(input); ok {
+		t.Errorf("load: after clear: want miss, got hit")
+	}
+}
@@ -0,0 +1,16 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package layouts
+
+// Error defines a constant error
+type Error string
+
+// Error implements the Errors interface
+func (e Error) Error() string { return string(e) }
+
+const (
+	ErrDuplicateLayout Error = "duplicate layout"
+	ErrMissingVersion  Error = "missing version"
+	ErrNoData          Error = "no data"
+	ErrUnknownLayout   Error = "unknown layout"
+)
@@ -0,0 +1,158 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package layouts detects the layout (format version) of a TribeNet turn report.
+//
+// The GM has changed the layout of the report several times, and older reports
+// don't parse the same way as newer ones. Each layout is identified by the turn
+// that it was introduced on (e.g. "899-12") and a set of markers, which are lines
+// that must be present in any report that uses the layout.
+//
+// Tokenizers and grammars are registered against the layout version, so adding
+// a new layout doesn't require changes to the callers.
+package layouts
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/playbymail/tribal/norm"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// Layout_t describes a single layout of the turn report.
+type Layout_t struct {
+	Version   string           // turn the layout was introduced, formatted as "899-12"
+	FirstTurn int              // turn number the layout was introduced
+	Descr     string           // short description of the layout
+	Markers   []*regexp.Regexp // every marker must match at least one line in the report
+}
+
+var (
+	// registry of known layouts, keyed by version
+	registry = struct {
+		sync.Mutex
+		layouts map[string]Layout_t
+	}{layouts: map[string]Layout_t{}}
+
+	reTurnLine = regexp.MustCompile(`^current turn (\d{3,4})-(\d{1,2})\(#(\d+)\)`)
+)
+
+func init() {
+	// the original layout. the unit header has both current and previous hex.
+	if err := Register(Layout_t{
+		Version:   "899-12",
+		FirstTurn: 0,
+		Descr:     "original layout",
+		Markers: []*regexp.Regexp{
+			regexp.MustCompile(`^(courier|element|fleet|garrison|tribe) \d{4}(?:[cefg]\d)?,.*,current hex = `),
+		},
+	}); err != nil {
+		panic(err)
+	}
+}
+
+// Register adds a layout to the registry.
+// Returns an error if the version is missing or has already been registered.
+func Register(l Layout_t) error {
+	if l.Version == "" {
+		return ErrMissingVersion
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	if _, ok := registry.layouts[l.Version]; ok {
+		return fmt.Errorf("%s: %w", l.Version, ErrDuplicateLayout)
+	}
+	registry.layouts[l.Version] = l
+	return nil
+}
+
+// Lookup returns the layout for the given version.
+func Lookup(version string) (Layout_t, bool) {
+	registry.Lock()
+	defer registry.Unlock()
+	l, ok := registry.layouts[version]
+	return l, ok
+}
+
+// Layouts returns all registered layouts, sorted by first turn with the most recent first.
+func Layouts() []Layout_t {
+	registry.Lock()
+	defer registry.Unlock()
+	var list []Layout_t
+	for _, l := range registry.layouts {
+		list = append(list, l)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].FirstTurn != list[j].FirstTurn {
+			return list[i].FirstTurn > list[j].FirstTurn
+		}
+		return list[i].Version > list[j].Version
+	})
+	return list
+}
+
+// Detect inspects the report and returns the version of the layout that it uses.
+// The input is the raw report text; we normalize it before inspecting it.
+//
+// We use the first "current turn" line to find the turn number of the report.
+// The candidate layouts are those that were introduced on or before that turn.
+// We return the most recent candidate whose markers all match the report.
+// If the report doesn't have a turn line, we only use the markers.
+func Detect(input []byte) (string, error) {
+	if len(input) == 0 {
+		return "", ErrNoData
+	}
+	lines := bytes.Split(norm.NormalizeSpaces(norm.NormalizeCase(norm.LineEndings(input))), []byte{'\n'})
+	turnNo, hasTurn := turnNumber(lines)
+	for _, l := range Layouts() {
+		if hasTurn && l.FirstTurn > turnNo {
+			continue
+		} else if !matchesAll(l.Markers, lines) {
+			continue
+		}
+		return l.Version, nil
+	}
+	return "", ErrUnknownLayout
+}
+
+// TurnNumber returns the turn number from the first "current turn" line in the report.
+// Returns false if the report doesn't have a turn line.
+func TurnNumber(input []byte) (int, bool) {
+	return turnNumber(bytes.Split(norm.NormalizeSpaces(norm.NormalizeCase(norm.LineEndings(input))), []byte{'\n'}))
+}
+
+// turnNumber expects normalized lines.
+func turnNumber(lines [][]byte) (int, bool) {
+	for _, line := range lines {
+		match := reTurnLine.FindSubmatch(line)
+		if match == nil {
+			continue
+		}
+		year, _ := strconv.Atoi(string(match[1]))
+		month, _ := strconv.Atoi(string(match[2]))
+		if !(899 <= year && year <= 9999 && 1 <= month && month <= 12) {
+			continue
+		}
+		return (year-899)*12 + month - 12, true
+	}
+	return 0, false
+}
+
+// matchesAll returns true if every marker matches at least one line.
+func matchesAll(markers []*regexp.Regexp, lines [][]byte) bool {
+	for _, marker := range markers {
+		found := false
+		for _, line := range lines {
+			if marker.Match(line) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package layouts_test
+
+import (
+	"errors"
+	"github.com/playbymail/tribal/parser/layouts"
+	"regexp"
+	"testing"
+)
+
+// the GM hasn't changed the layout yet, so the tests register a made-up
+// layout that starts on turn 901-03 and adds a weather line to the report.
+func init() {
+	if err := layouts.Register(layouts.Layout_t{
+		Version:   "901-03",
+		FirstTurn: 15,
+		Descr:     "test layout",
+		Markers: []*regexp.Regexp{
+			regexp.MustCompile(`^(courier|element|fleet|garrison|tribe) \d{4}(?:[cefg]\d)?,.*,current hex = `),
+			regexp.MustCompile(`^weather:`),
+		},
+	}); err != nil {
+		panic(err)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		input   string
+		version string
+		err     error
+	}{
+		{
+			name:    "setup report",
+			input:   "Tribe 0987, , Current Hex = ## 0608, (Previous Hex = N/A)\nCurrent Turn 899-12 (#0), Winter, FINE\n",
+			version: "899-12",
+		},
+		{
+			name:    "no turn line",
+			input:   "Tribe 0987, , Current Hex = KP 0608, (Previous Hex = ## 0608)\n",
+			version: "899-12",
+		},
+		{
+			name:    "turn before the new layout",
+			input:   "Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0608)\nCurrent Turn 901-02 (#14), Winter, FINE\nWeather: FINE\n",
+			version: "899-12",
+		},
+		{
+			name:    "first turn of the new layout",
+			input:   "Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0608)\nCurrent Turn 901-03 (#15), Spring, FINE\nWeather: FINE\n",
+			version: "901-03",
+		},
+		{
+			name:    "new turn without the new markers",
+			input:   "Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0608)\nCurrent Turn 901-03 (#15), Spring, FINE\n",
+			version: "899-12",
+		},
+		{
+			name:    "new markers without a turn line",
+			input:   "Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0608)\nWeather: FINE\n",
+			version: "901-03",
+		},
+		{
+			name:  "not a report",
+			input: "Dear Clan,\nYour turn is late.\n",
+			err:   layouts.ErrUnknownLayout,
+		},
+		{
+			name: "empty",
+			err:  layouts.ErrNoData,
+		},
+	} {
+		version, err := layouts.Detect([]byte(tc.input))
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s: err: want %v, got %v", tc.name, tc.err, err)
+		} else if version != tc.version {
+			t.Errorf("%s: version: want %q, got %q", tc.name, tc.version, version)
+		}
+	}
+}
+
+func TestTurnNumber(t *testing.T) {
+	if turnNo, ok := layouts.TurnNumber([]byte("Current Turn 900-05 (#5), Summer, FINE")); !ok || turnNo != 5 {
+		t.Errorf("turn: want 5, true: got %d, %v", turnNo, ok)
+	}
+}
+
+func TestLayouts(t *testing.T) {
+	var versions []string
+	for _, l := range layouts.Layouts() {
+		versions = append(versions, l.Version)
+	}
+	if len(versions) != 2 || versions[0] != "901-03" || versions[1] != "899-12" {
+		t.Errorf("layouts: want [901-03 899-12], got %v", versions)
+	}
+	if err := layouts.Register(layouts.Layout_t{Version: "901-03"}); !errors.Is(err, layouts.ErrDuplicateLayout) {
+		t.Errorf("register: want %v, got %v", layouts.ErrDuplicateLayout, err)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package pigeon
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/parser/pigeon/rpt89912"
+	"sync"
+)
+
+// Grammar_t parses the input starting with the entrypoint rule.
+// The grammars are generated by pigeon, so this is usually a thin
+// wrapper around the generated Parse function.
+type Grammar_t func(filename string, input []byte, entrypoint string) (any, error)
+
+var (
+	// grammars is the registry of grammars, keyed by layout version.
+	grammars = struct {
+		sync.Mutex
+		grammars map[string]Grammar_t
+	}{grammars: map[string]Grammar_t{}}
+)
+
+func init() {
+	RegisterGrammar("899-12", func(filename string, input []byte, entrypoint string) (any, error) {
+		return rpt89912.Parse(filename, input, rpt89912.Entrypoint(entrypoint))
+	})
+}
+
+// RegisterGrammar adds a grammar for a layout version to the registry.
+// Versions should be registered with the layouts package, too.
+// Panics if the version has already been registered.
+func RegisterGrammar(version string, g Grammar_t) {
+	grammars.Lock()
+	defer grammars.Unlock()
+	if _, ok := grammars.grammars[version]; ok {
+		panic(fmt.Sprintf("assert(grammar %q not registered)", version))
+	}
+	grammars.grammars[version] = g
+}
+
+// lookupGrammar returns the grammar for the version.
+func lookupGrammar(version string) (Grammar_t, bool) {
+	grammars.Lock()
+	defer grammars.Unlock()
+	g, ok := grammars.grammars[version]
+	return g, ok
+}
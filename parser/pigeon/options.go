@@ -29,3 +29,12 @@ func WithHash(hash string) Option_t {
 		return nil
 	}
 }
+
+// WithVersion sets the layout version of the input.
+// If not set, the parser will inspect the input to detect the version.
+func WithVersion(version string) Option_t {
+	return func(p *Parser) error {
+		p.version = version
+		return nil
+	}
+}
@@ -3,6 +3,9 @@
 package pigeon
 
 import (
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/parser/layouts"
 	"log"
 	"time"
 )
@@ -26,9 +29,11 @@ func init() {
 }
 
 type Parser struct {
-	pos      int    // current position in the input (offset of next character to read)
-	input    []byte // input data, not normalized and caller must not modify
-	pushback []byte // pushback buffer for look-ahead input
+	pos      int       // current position in the input (offset of next character to read)
+	input    []byte    // input data, not normalized and caller must not modify
+	pushback []byte    // pushback buffer for look-ahead input
+	version  string    // layout version of the input
+	grammar  Grammar_t // grammar for the layout version
 }
 
 func New(options ...Option_t) (*Parser, error) {
@@ -38,6 +43,22 @@ func New(options ...Option_t) (*Parser, error) {
 			return nil, err
 		}
 	}
+	// detect the layout if the caller didn't provide one
+	if p.version == "" {
+		if len(p.input) == 0 {
+			return nil, ErrNoData
+		}
+		version, err := layouts.Detect(p.input)
+		if err != nil {
+			return nil, err
+		}
+		p.version = version
+	}
+	if g, ok := lookupGrammar(p.version); !ok {
+		return nil, fmt.Errorf("version: %s: unsupported version", p.version)
+	} else {
+		p.grammar = g
+	}
 	return p, nil
 }
 
@@ -48,9 +69,11 @@ func (p *Parser) Free() {
 type Node any
 
 // Parse returns the root node of the parse tree.
+// For now, the root is the list of units found in the report.
 func (p *Parser) Parse() (Node, error) {
 	started := time.Now()
 	noUnits := 0
+	var units []*ast.Unit_t
 	defer func() {
 		log.Printf("pigeon: parse read %d unit sections\n", noUnits)
 		log.Printf("pigeon: parse completed in %v\n", time.Since(started))
@@ -61,9 +84,11 @@ func (p *Parser) Parse() (Node, error) {
 	// that level returns the number of lines parsed and the highest level error it encountered.
 	for line := p.NextLine(); line != nil; line = p.NextLine() {
 		// is this line a unit heading?
-		if _, ok := acceptUnitId(line); !ok {
+		unit, ok := p.acceptUnitId(line)
+		if !ok {
 			continue
 		}
+		units = append(units, &unit)
 		noUnits++
 		//// is this line a turn number?
 		//if turnLine := turns.ParseTurnLine(path, line); turnLine != nil {
@@ -84,7 +109,7 @@ func (p *Parser) Parse() (Node, error) {
 		//// is this line a tribe status?
 	}
 
-	return nil, nil
+	return units, nil
 }
 
 // Lines returns the entire set of lines in the input.
@@ -213,28 +213,7 @@ func (p *parser) callonUnitId1() (any, error) {
 }
 
 func (c *current) onUNIT_ID1() (any, error) {
-	u := ast.Unit_t{
-		No: int(c.text[0]-'0')*1000 + int(c.text[1]-'0')*100 + int(c.text[2]-'0')*10 + int(c.text[3]-'0'),
-	}
-	if len(c.text) == 4 {
-		u.Kind = ast.Tribe
-		u.Seq = int(c.text[0] - '0')
-	} else {
-		switch c.text[4] {
-		case 'c':
-			u.Kind = ast.Courier
-		case 'e':
-			u.Kind = ast.Element
-		case 'f':
-			u.Kind = ast.Fleet
-		case 'g':
-			u.Kind = ast.Garrison
-		default:
-			panic(fmt.Sprintf("assert(kind != '%c')", c.text[6]))
-		}
-		u.Seq = int(c.text[5] - '0')
-	}
-	return u, nil
+	return ast.Unit_t{Id: ast.UnitId_t(c.text)}, nil
 }
 
 func (p *parser) callonUNIT_ID1() (any, error) {
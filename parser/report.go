@@ -9,9 +9,11 @@ import (
 	"fmt"
 	"github.com/playbymail/tribal/docx"
 	"github.com/playbymail/tribal/norm"
+	"github.com/playbymail/tribal/odt"
 	"github.com/playbymail/tribal/parser/ast"
 	"github.com/playbymail/tribal/parser/turns"
 	"github.com/playbymail/tribal/parser/units"
+	"github.com/playbymail/tribal/rtf"
 	"github.com/playbymail/tribal/text"
 	"log"
 )
@@ -42,8 +44,12 @@ func Report(path string, options ...Option_t) (*Report_t, error) {
 	var lines [][]byte
 	switch docx.DetectWordDocType(rpt.options.Data) {
 	case docx.Doc:
-		rpt.Error = ErrInvalidFileType
-		return rpt, rpt.Error
+		if wordLines, err := docx.ReadDoc(rpt.options.Data); err != nil {
+			rpt.Error = err
+			return rpt, err
+		} else {
+			lines = wordLines
+		}
 	case docx.Docx:
 		if wordLines, err := docx.Read(rpt.options.Data); err != nil {
 			rpt.Error = err
@@ -51,6 +57,20 @@ func Report(path string, options ...Option_t) (*Report_t, error) {
 		} else {
 			lines = wordLines
 		}
+	case docx.Odt:
+		if odtLines, err := odt.Read(rpt.options.Data); err != nil {
+			rpt.Error = err
+			return rpt, err
+		} else {
+			lines = odtLines
+		}
+	case docx.Rtf:
+		if rtfLines, err := rtf.Read(rpt.options.Data); err != nil {
+			rpt.Error = err
+			return rpt, err
+		} else {
+			lines = rtfLines
+		}
 	default:
 		if textLines, err := text.Read(rpt.options.Data); err != nil {
 			rpt.Error = err
@@ -63,7 +83,7 @@ func Report(path string, options ...Option_t) (*Report_t, error) {
 	// normalize the input. this is what we will save to the database.
 	// there are some users that can't edit Word documents.
 	// maybe we'll allow them to use this file.
-	// skips any empty lines.
+	// skips any empty lines, so we remember where each line came from.
 	for n, line := range lines {
 		// normalize the input
 		line = norm.NormalizeSpaces(norm.NormalizeCase(norm.RemoveBadUtf8(line)))
@@ -73,8 +93,10 @@ func Report(path string, options ...Option_t) (*Report_t, error) {
 		if rpt.Lines == nil {
 			// cheap attempt to optimize memory allocation
 			rpt.Lines = make([][]byte, 0, len(lines)-n)
+			rpt.Sources = make([]int, 0, len(lines)-n)
 		}
 		rpt.Lines = append(rpt.Lines, line)
+		rpt.Sources = append(rpt.Sources, n+1)
 	}
 
 	// highest level loop in the parser looks for unit headings.
@@ -0,0 +1,45 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package scanner
+
+import (
+	"fmt"
+	"sync"
+)
+
+// TokenizerFactory returns a new tokenizer for the input.
+type TokenizerFactory func(input []byte) Tokenizer
+
+var (
+	// tokenizers is the registry of tokenizers, keyed by layout version.
+	tokenizers = struct {
+		sync.Mutex
+		factories map[string]TokenizerFactory
+	}{factories: map[string]TokenizerFactory{}}
+)
+
+func init() {
+	RegisterTokenizer("899-12", func(input []byte) Tokenizer {
+		return &tokenizer_899_12{input: input, length: len(input)}
+	})
+}
+
+// RegisterTokenizer adds a tokenizer for a layout version to the registry.
+// Versions should be registered with the layouts package, too.
+// Panics if the version has already been registered.
+func RegisterTokenizer(version string, factory TokenizerFactory) {
+	tokenizers.Lock()
+	defer tokenizers.Unlock()
+	if _, ok := tokenizers.factories[version]; ok {
+		panic(fmt.Sprintf("assert(tokenizer %q not registered)", version))
+	}
+	tokenizers.factories[version] = factory
+}
+
+// lookupTokenizer returns the tokenizer factory for the version.
+func lookupTokenizer(version string) (TokenizerFactory, bool) {
+	tokenizers.Lock()
+	defer tokenizers.Unlock()
+	factory, ok := tokenizers.factories[version]
+	return factory, ok
+}
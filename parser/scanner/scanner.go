@@ -8,21 +8,32 @@ package scanner
 
 import (
 	"fmt"
+	"github.com/playbymail/tribal/parser/layouts"
 	"strings"
 )
 
+// NewFromReport returns a new scanner for the report input.
+// It inspects the report to find the version of the layout.
+func NewFromReport(input []byte) (*Scanner, error) {
+	version, err := layouts.Detect(input)
+	if err != nil {
+		return nil, fmt.Errorf("version: %w", err)
+	}
+	return New(input, version)
+}
+
 // New returns a new scanner for the report input.
 // Version expects a string of the form "899-12" or "902-05."
+// The tokenizer for the version must be registered.
 func New(input []byte, version string) (*Scanner, error) {
-	var tk Tokenizer
-	switch version {
-	case "":
+	if version == "" {
 		return nil, fmt.Errorf("version: missing version")
-	case "899-12":
-		tk = &tokenizer_899_12{input: input, length: len(input)}
-	default:
+	}
+	factory, ok := lookupTokenizer(version)
+	if !ok {
 		return nil, fmt.Errorf("version: %s: unsupported version", version)
 	}
+	tk := factory(input)
 	// create a scanner from the tokenizer.
 	s := &Scanner{
 		head: &Token{Type: BOF},
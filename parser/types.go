@@ -14,9 +14,21 @@ type Report_t struct {
 	Sections []*Section_t
 	Error    error    // highest level error encountered while parsing the report
 	Lines    [][]byte // copy of the input after normalization
+	Sources  []int    // line or paragraph number in the original input for each line in Lines
 	options  Options_t
 }
 
+// Source returns the 1-based line or paragraph number in the original input
+// for the 1-based line number in the normalized input. The line numbers that
+// section.Parser.Split reports are in the normalized input when it is given
+// the lines joined with newlines. Returns 0 if the line is out of range.
+func (r *Report_t) Source(line int) int {
+	if !(1 <= line && line <= len(r.Sources)) {
+		return 0
+	}
+	return r.Sources[line-1]
+}
+
 type Turn_t struct {
 	No    int
 	Year  int
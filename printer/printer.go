@@ -0,0 +1,346 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package printer writes units back out as report text.
+//
+// The output is the canonical form of the report: one line per kind,
+// with the punctuation that the spec calls for. It doesn't recover the
+// lines that the parsers ignore, and it can't recover the case of names,
+// because the splitter forces everything to lower case.
+//
+// Running the output through section.Parser.Split and Section.Parse should
+// return the same units that were printed.
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/playbymail/tribal/border"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/item"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/passage"
+	"github.com/playbymail/tribal/resource"
+	"github.com/playbymail/tribal/terrain"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Report returns the text for all the units, with a blank line between sections.
+func Report(units []*ast.Unit_t) []byte {
+	b := &bytes.Buffer{}
+	for n, u := range units {
+		if n > 0 {
+			b.WriteByte('\n')
+		}
+		b.Write(Unit(u))
+	}
+	return b.Bytes()
+}
+
+// Unit returns the lines for a single unit, including the trailing newline.
+func Unit(u *ast.Unit_t) []byte {
+	b := &bytes.Buffer{}
+	writeLine := func(line []byte) {
+		if len(line) != 0 {
+			b.Write(line)
+			b.WriteByte('\n')
+		}
+	}
+	writeLine(UnitHeader(u))
+	if u.Turn != nil {
+		writeLine(TurnLine(u.Turn))
+	}
+	if u.Moves != nil {
+		writeLine(Movement(u.Moves))
+		for _, line := range ScoutLines(u.Moves.Patrols) {
+			writeLine(line)
+		}
+	}
+	if u.Status != nil {
+		writeLine(Status(u.Id, u.Status))
+	}
+	if u.Inventory != nil {
+		for _, line := range Inventory(u.Inventory) {
+			writeLine(line)
+		}
+	}
+	return b.Bytes()
+}
+
+// UnitHeader returns the unit header line.
+//
+//	Tribe 0987, , Current Hex = KP 0608, (Previous Hex = ## 0608)
+func UnitHeader(u *ast.Unit_t) []byte {
+	return []byte(fmt.Sprintf("%s %s, %s, Current Hex = %s, (Previous Hex = %s)", unitKind(u.Id), u.Id, u.Name, coordinates(u.CurrentHex), coordinates(u.PreviousHex)))
+}
+
+// TurnLine returns the turn line.
+// The season and weather are only written if the turn has them.
+// The next turn and report date are only in the clan's section. They are
+// separated from the current turn by a tab.
+//
+//	Current Turn 900-05 (#5), Summer, FINE\tNext Turn 900-06 (#6), 14/01/2024
+func TurnLine(t *ast.Turn_t) []byte {
+	line := fmt.Sprintf("Current Turn %d-%02d (#%d)", t.Year, t.Month, t.Id)
+	if t.Season != "" && t.Weather != "" {
+		line += fmt.Sprintf(", %s, %s", title(t.Season), strings.ToUpper(t.Weather))
+	}
+	if t.Next != nil {
+		line += fmt.Sprintf("\tNext Turn %d-%02d (#%d), %s", t.Next.Year, t.Next.Month, t.Next.Id, t.ReportDate)
+	}
+	return []byte(line)
+}
+
+// Movement returns the unit's movement line.
+// A unit can have only one of follows, goes to, or marches.
+func Movement(m *ast.Moves_t) []byte {
+	if m.Follows != nil {
+		return []byte(fmt.Sprintf("Tribe Follows %s", m.Follows.Follows))
+	} else if m.GoesTo != nil {
+		return []byte(fmt.Sprintf("Tribe Goes to %s", coordinates(m.GoesTo.GoesTo)))
+	}
+	return TribeMovement(m.Marches)
+}
+
+// TribeMovement returns the tribe movement line.
+//
+//	Tribe Movement: Move NE-PR\SE-PR,O S W,Ford SE\Not enough M.P's to move to SW into GRASSY HILLS
+func TribeMovement(marches []*ast.March_t) []byte {
+	segments := []string{"Tribe Movement: Move"}
+	for _, m := range marches {
+		if m.Direction != direction.None {
+			fields := []string{fmt.Sprintf("%s-%s", m.Direction, m.Terrain)}
+			fields = append(fields, observations(m.Neighbors, m.Borders, m.Passages)...)
+			if m.HexName != nil {
+				fields = append(fields, m.HexName.Name)
+			}
+			if m.Errors != nil {
+				fields = append(fields, m.Errors.ExcessInput...)
+			}
+			segments = append(segments, strings.Join(fields, ","))
+		} else if text, ok := failure(m.Failure, m.Neighbors, m.Borders); ok {
+			segments = append(segments, text)
+		} else if m.Errors != nil {
+			segments = append(segments, m.Errors.ExcessInput...)
+		}
+	}
+	if len(segments) == 1 {
+		return []byte(segments[0])
+	}
+	return []byte(segments[0] + " " + strings.Join(segments[1:], "\\"))
+}
+
+// ScoutLines returns one line for each scout, in the order the scouts
+// first appear in the list of patrols.
+func ScoutLines(patrols []*ast.Patrol_t) (lines [][]byte) {
+	var order []int
+	byScout := map[int][]*ast.Patrol_t{}
+	for _, p := range patrols {
+		if _, ok := byScout[p.Patrol]; !ok {
+			order = append(order, p.Patrol)
+		}
+		byScout[p.Patrol] = append(byScout[p.Patrol], p)
+	}
+	for _, no := range order {
+		lines = append(lines, ScoutLine(no, byScout[no]))
+	}
+	return lines
+}
+
+// ScoutLine returns the line for a single scout.
+//
+//	Scout 2: Scout N-PR\N-RH,O NW N,Find Iron Ore,0987 0987c2\Can't move on Ocean to N of HEX,Patrolled and found 0987 0987c2
+func ScoutLine(no int, patrols []*ast.Patrol_t) []byte {
+	segments := []string{fmt.Sprintf("Scout %d: Scout", no)}
+	for _, p := range patrols {
+		if p.Direction != direction.None {
+			fields := []string{fmt.Sprintf("%s-%s", p.Direction, p.Terrain)}
+			fields = append(fields, observations(p.Neighbors, p.Borders, p.Passages)...)
+			for _, r := range p.Resources {
+				fields = append(fields, "Find "+resource.EnumToString[r])
+			}
+			if p.HexName != nil {
+				fields = append(fields, p.HexName.Name)
+			}
+			if len(p.Encounters) != 0 {
+				fields = append(fields, unitList(p.Encounters))
+			}
+			if p.Errors != nil {
+				fields = append(fields, p.Errors.ExcessInput...)
+			}
+			segments = append(segments, strings.Join(fields, ","))
+		} else if text, ok := failure(p.Failure, p.Neighbors, p.Borders); ok {
+			segments = append(segments, text)
+		} else if len(p.Encounters) != 0 {
+			segments = append(segments, "Patrolled and found "+unitList(p.Encounters))
+		} else if len(p.Items) != 0 {
+			for _, i := range p.Items {
+				segments = append(segments, fmt.Sprintf("Find %d %s", i.Quantity, item.EnumToString[i.Item]))
+			}
+		} else if p.Errors != nil && len(p.Errors.ExcessInput) != 0 {
+			segments = append(segments, p.Errors.ExcessInput...)
+		} else {
+			segments = append(segments, "Nothing of interest found")
+		}
+	}
+	if len(segments) == 1 {
+		return []byte(segments[0])
+	}
+	return []byte(segments[0] + " " + strings.Join(segments[1:], "\\"))
+}
+
+// Status returns the unit status line.
+//
+//	0987c1 Status: GRASSY HILLS,Los Angeles,O SW,River N,Ford NW,0987c1 0987e1
+func Status(id ast.UnitId_t, s *ast.Status_t) []byte {
+	if s.Unit != "" {
+		id = s.Unit
+	}
+	fields := []string{terrainName(s.Tile.Terrain)}
+	if s.Tile.HexName != nil {
+		fields = append(fields, s.Tile.HexName.Name)
+	}
+	for _, r := range s.Tile.Resources {
+		fields = append(fields, resource.EnumToString[r])
+	}
+	fields = append(fields, observations(s.Tile.Neighbors, s.Tile.Borders, s.Tile.Passages)...)
+	if len(s.Tile.Encounters) != 0 {
+		fields = append(fields, unitList(s.Tile.Encounters))
+	}
+	if s.Errors != nil {
+		fields = append(fields, s.Errors.ExcessInput...)
+	}
+	return []byte(fmt.Sprintf("%s Status: %s", id, strings.Join(fields, ",")))
+}
+
+// Inventory returns the people and goods blocks.
+// Input that the parser didn't recognize is written on separate lines at the end.
+//
+//	Humans
+//	People 6160, Warriors 1500, Actives 1500, Inactives 3160
+//	Possessions
+//	Cattle 300, Horses 1250
+func Inventory(inv *ast.Inventory_t) (lines [][]byte) {
+	if p := inv.Population; p != nil {
+		lines = append(lines, []byte("Humans"))
+		lines = append(lines, []byte(fmt.Sprintf("People %d, Warriors %d, Actives %d, Inactives %d", p.People, p.Warriors, p.Actives, p.Inactives)))
+	}
+	if len(inv.Items) != 0 || (inv.Errors != nil && len(inv.Errors.ExcessInput) != 0) {
+		lines = append(lines, []byte("Possessions"))
+	}
+	var fields []string
+	for _, i := range inv.Items {
+		fields = append(fields, fmt.Sprintf("%s %d", item.EnumToString[i.Item], i.Quantity))
+	}
+	if len(fields) != 0 {
+		lines = append(lines, []byte(strings.Join(fields, ", ")))
+	}
+	if inv.Errors != nil {
+		for _, excess := range inv.Errors.ExcessInput {
+			lines = append(lines, []byte(excess))
+		}
+	}
+	return lines
+}
+
+// coordinates returns the coordinates as they appear in the report.
+func coordinates(c ast.Coordinates_t) string {
+	if c.IsZero() {
+		return "N/A"
+	}
+	return c.String()
+}
+
+// directions returns a space separated list of directions.
+func directions(list []direction.Direction_e) string {
+	var s []string
+	for _, d := range list {
+		s = append(s, d.String())
+	}
+	return strings.Join(s, " ")
+}
+
+// failure returns the text for a failed move.
+// The neighbor or border that blocked the move is the first one in the list.
+func failure(reason ast.MoveFailure_e, neighbors []*ast.Neighbor_t, borders []*ast.Border_t) (string, bool) {
+	switch reason {
+	case ast.NoFord:
+		if len(borders) == 0 || len(borders[0].Direction) == 0 {
+			return "", false
+		}
+		return fmt.Sprintf("No Ford on %s to %s of HEX", border.EnumToString[borders[0].Border], borders[0].Direction[0]), true
+	case ast.CantMoveOnWater, ast.CantMoveWagons, ast.NotEnoughMPs:
+		if len(neighbors) == 0 || len(neighbors[0].Direction) == 0 {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	n := neighbors[0]
+	switch reason {
+	case ast.CantMoveOnWater:
+		return fmt.Sprintf("Can't move on %s to %s of HEX", title(terrainName(n.Terrain)), n.Direction[0]), true
+	case ast.CantMoveWagons:
+		return fmt.Sprintf("Cannot Move Wagons into Swamp/Jungle Hill to %s of HEX", n.Direction[0]), true
+	}
+	return fmt.Sprintf("Not enough M.P's to move to %s into %s", n.Direction[0], terrainName(n.Terrain)), true
+}
+
+// observations returns the neighbors, borders, and passages as report fields.
+func observations(neighbors []*ast.Neighbor_t, borders []*ast.Border_t, passages []*ast.Passage_t) (fields []string) {
+	for _, n := range neighbors {
+		fields = append(fields, fmt.Sprintf("%s %s", n.Terrain, directions(n.Direction)))
+	}
+	for _, b := range borders {
+		fields = append(fields, fmt.Sprintf("%s %s", border.EnumToString[b.Border], directions(b.Direction)))
+	}
+	for _, p := range passages {
+		fields = append(fields, fmt.Sprintf("%s %s", passage.EnumToString[p.Passage], directions(p.Direction)))
+	}
+	return fields
+}
+
+// terrainName returns the long name for the terrain, falling back to the code.
+func terrainName(t terrain.Terrain_e) string {
+	if name, ok := terrain.EnumToLongName[t]; ok {
+		return name
+	}
+	return t.String()
+}
+
+// title returns the text in lower case with the first letter of each word in upper case.
+func title(text string) string {
+	words := strings.Fields(strings.ToLower(text))
+	for i, word := range words {
+		r, w := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToUpper(r)) + word[w:]
+	}
+	return strings.Join(words, " ")
+}
+
+// unitKind returns the kind of unit, which is derived from the unit id.
+func unitKind(id ast.UnitId_t) string {
+	if len(id) == 6 {
+		switch id[4] {
+		case 'c':
+			return "Courier"
+		case 'e':
+			return "Element"
+		case 'f':
+			return "Fleet"
+		case 'g':
+			return "Garrison"
+		}
+	}
+	return "Tribe"
+}
+
+// unitList returns a space separated list of unit ids.
+func unitList(list []ast.UnitId_t) string {
+	var s []string
+	for _, id := range list {
+		s = append(s, string(id))
+	}
+	return strings.Join(s, " ")
+}
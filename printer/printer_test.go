@@ -0,0 +1,120 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package printer_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/printer"
+	"github.com/playbymail/tribal/section"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// every report in the section corpus should round-trip through the printer:
+// the printed units must parse back to the same units, and printing those
+// must return the same text.
+func TestRoundTrip(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	paths, err := filepath.Glob(filepath.Join("..", "section", "testdata", "*.report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) == 0 {
+		t.Fatal("testdata: no reports found")
+	}
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			input, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			units := parse(t, path, input)
+			text := printer.Report(units)
+			again := parse(t, path, text)
+			// the printer doesn't keep the layout of the report, so line numbers aren't compared
+			clearLines(units)
+			clearLines(again)
+			want, got := marshal(t, units), marshal(t, again)
+			if !bytes.Equal(want, got) {
+				t.Errorf("units do not round-trip\nprinted:\n%s\nwant:\n%s\ngot:\n%s", text, want, got)
+			}
+			if text2 := printer.Report(again); !bytes.Equal(text, text2) {
+				t.Errorf("printed text is not stable\nfirst:\n%s\nsecond:\n%s", text, text2)
+			}
+		})
+	}
+}
+
+func parse(t *testing.T, path string, input []byte) (units []*ast.Unit_t) {
+	t.Helper()
+	for _, s := range section.New(section.DefaultConfig()).Split(input) {
+		if err := s.Parse(path); err != nil {
+			t.Fatalf("section %d: %v", s.Id, err)
+		}
+		units = append(units, s.Unit)
+	}
+	return units
+}
+
+func marshal(t *testing.T, units []*ast.Unit_t) []byte {
+	t.Helper()
+	buf, err := json.MarshalIndent(units, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+func clearLines(units []*ast.Unit_t) {
+	for _, u := range units {
+		if u.Moves != nil {
+			for _, step := range u.Moves.Marches {
+				step.Line = 0
+			}
+			for _, step := range u.Moves.Patrols {
+				step.Line = 0
+			}
+		}
+		if u.Status != nil {
+			u.Status.Line = 0
+		}
+	}
+}
+
+// the clan's section has the next turn and report date on the turn line,
+// and they must survive the round trip.
+func TestRoundTripClanSection(t *testing.T) {
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	const turnLine = "Current Turn 900-05 (#5), Summer, FINE\tNext Turn 900-06 (#6), 14/01/2024"
+	input := []byte("Tribe 0987, , Current Hex = KP 0608, (Previous Hex = ## 0608)\n" + turnLine + "\n")
+	units := parse(t, "clan.report.txt", input)
+	if len(units) != 1 || units[0].Turn == nil || units[0].Turn.Next == nil {
+		t.Fatalf("units: want 1 with a next turn, got %d", len(units))
+	}
+	text := printer.Report(units)
+	if lines := bytes.Split(text, []byte{'\n'}); len(lines) < 2 || string(lines[1]) != turnLine {
+		t.Errorf("turn line: want %q, got\n%s", turnLine, text)
+	}
+	if want, got := marshal(t, units), marshal(t, parse(t, "clan.report.txt", text)); !bytes.Equal(want, got) {
+		t.Errorf("units do not round-trip\nwant:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestTurnLine(t *testing.T) {
+	turn := &ast.Turn_t{Id: 5, Year: 900, Month: 5, Season: "summer", Weather: "fine"}
+	if got, want := string(printer.TurnLine(turn)), "Current Turn 900-05 (#5), Summer, FINE"; got != want {
+		t.Errorf("turn line: want %q, got %q", want, got)
+	}
+	turn.Next, turn.ReportDate = &ast.Turn_t{Id: 6, Year: 900, Month: 6}, "14/01/2024"
+	if got, want := string(printer.TurnLine(turn)), "Current Turn 900-05 (#5), Summer, FINE\tNext Turn 900-06 (#6), 14/01/2024"; got != want {
+		t.Errorf("turn line: want %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/playbymail/tribal/border"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/passage"
+	"github.com/playbymail/tribal/terrain"
+	"github.com/playbymail/tribal/tiles"
+	"io"
+)
+
+// The ASCII map draws each tile as a cell that is six characters wide and
+// two lines tall. Even columns are pushed down half a cell, which matches
+// the even-q layout of the map.
+//
+//	\PR  /    NW edge, terrain code, N edge, NE edge
+//	/*@! \    SW edge, markers, S edge, SE edge
+//
+// Edges show rivers (~), canals (=), fords (F), passes (P) and stone roads (#).
+// Markers show settlements (*), our units (@), other units (!) and resources ($).
+const (
+	asciiCellWidth  = 6
+	asciiRulerWidth = 5
+)
+
+// ASCIIOptions_t controls the ASCII renderer.
+type ASCIIOptions_t struct {
+	Color  bool // use ANSI colors for the terrain
+	Legend bool // print the legend after the map
+}
+
+// ASCII draws the tiles in the view.
+func ASCII(w io.Writer, m *tiles.Map_t, v View_t, opts ASCIIOptions_t) error {
+	b := &bytes.Buffer{}
+
+	// the top rulers show the grid column letter and the column number
+	b.WriteString(fmt.Sprintf("%*s", asciiRulerWidth, ""))
+	for col := v.TopLeft.Column; col <= v.BottomRight.Column; col++ {
+		c := tiles.Point_t{Column: col, Row: v.TopLeft.Row}.Coordinates()
+		if col == v.TopLeft.Column || c.Column == 1 {
+			b.WriteString(fmt.Sprintf(" %-*c", asciiCellWidth-1, 'A'+c.GridColumn-1))
+		} else {
+			b.WriteString(fmt.Sprintf("%*s", asciiCellWidth, ""))
+		}
+	}
+	b.WriteByte('\n')
+	b.WriteString(fmt.Sprintf("%*s", asciiRulerWidth, ""))
+	for col := v.TopLeft.Column; col <= v.BottomRight.Column; col++ {
+		c := tiles.Point_t{Column: col, Row: v.TopLeft.Row}.Coordinates()
+		b.WriteString(fmt.Sprintf(" %02d%*s", c.Column, asciiCellWidth-3, ""))
+	}
+	b.WriteByte('\n')
+
+	rows := v.Rows()
+	for y := 0; y <= 2*rows; y++ {
+		// the left ruler shows the grid row letter and the row number of the odd columns
+		if y%2 == 0 && y/2 < rows {
+			c := tiles.Point_t{Column: v.TopLeft.Column, Row: v.TopLeft.Row + y/2}.Coordinates()
+			b.WriteString(fmt.Sprintf("%c %02d ", 'A'+c.GridRow-1, c.Row))
+		} else {
+			b.WriteString(fmt.Sprintf("%*s", asciiRulerWidth, ""))
+		}
+		for col := v.TopLeft.Column; col <= v.BottomRight.Column; col++ {
+			yy := y
+			if col%2 == 0 {
+				yy--
+			}
+			if yy < 0 || yy >= 2*rows {
+				b.WriteString(fmt.Sprintf("%*s", asciiCellWidth, ""))
+				continue
+			}
+			p := tiles.Point_t{Column: col, Row: v.TopLeft.Row + yy/2}
+			b.WriteString(asciiCell(m, m.Tiles[p], yy%2, opts.Color))
+		}
+		b.WriteByte('\n')
+	}
+
+	if opts.Legend {
+		b.WriteString("\nedges: ~ river  = canal  F ford  P pass  # stone road\n")
+		b.WriteString("marks: * settlement  @ our unit  ! other unit  $ resource\n")
+	}
+
+	_, err := w.Write(b.Bytes())
+	return err
+}
+
+// asciiCell returns one line of the cell for a tile.
+func asciiCell(m *tiles.Map_t, t *tiles.Tile_t, line int, color bool) string {
+	if t == nil {
+		if line == 0 {
+			return "  .   "
+		}
+		return "      "
+	}
+
+	var text string
+	if line == 0 {
+		code := terrain.EnumToString[t.Terrain]
+		if len(code) > 3 {
+			code = code[:3]
+		} else if code == "" {
+			code = "?"
+		}
+		text = fmt.Sprintf("%c%-3s%c%c", edgeGlyph(t, direction.NorthWest, '\\'), code, edgeGlyph(t, direction.North, ' '), edgeGlyph(t, direction.NorthEast, '/'))
+	} else {
+		marks := []byte("   ")
+		if t.HexName != nil {
+			marks[0] = '*'
+		}
+		if len(t.Units) != 0 {
+			marks[1] = '@'
+		}
+		for _, id := range t.Encounters {
+			if m.IsForeign(id) {
+				marks[2] = '!'
+				break
+			}
+		}
+		if marks[2] == ' ' && len(t.Resources) != 0 {
+			marks[2] = '$'
+		}
+		text = fmt.Sprintf("%c%s%c%c", edgeGlyph(t, direction.SouthWest, '/'), marks, edgeGlyph(t, direction.South, ' '), edgeGlyph(t, direction.SouthEast, '\\'))
+	}
+	if !color {
+		return text
+	}
+	bg := terrainColor(t.Terrain)
+	fg := "30" // black
+	if isDark(bg) {
+		fg = "97" // bright white
+	}
+	return fmt.Sprintf("\x1b[%s;48;2;%d;%d;%dm%s\x1b[0m", fg, bg.R, bg.G, bg.B, text)
+}
+
+// edgeGlyph returns the character for the edge of the tile in the given direction.
+// Passages are shown instead of the border they cross.
+func edgeGlyph(t *tiles.Tile_t, d direction.Direction_e, none byte) byte {
+	switch t.Passages[d] {
+	case passage.Ford:
+		return 'F'
+	case passage.Pass:
+		return 'P'
+	case passage.StoneRoad:
+		return '#'
+	}
+	switch t.Borders[d] {
+	case border.Canal:
+		return '='
+	case border.River:
+		return '~'
+	}
+	return none
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package render
+
+import (
+	"github.com/playbymail/tribal/terrain"
+	"image/color"
+)
+
+// TerrainColors is the fill color for each terrain type.
+// Every renderer uses the same palette so that the maps look alike.
+var TerrainColors = map[terrain.Terrain_e]color.RGBA{
+	terrain.Blank:                {R: 0x30, G: 0x30, B: 0x30, A: 0xff},
+	terrain.Alps:                 {R: 0xe8, G: 0xe8, B: 0xf0, A: 0xff},
+	terrain.AridHills:            {R: 0xc8, G: 0xa8, B: 0x6c, A: 0xff},
+	terrain.AridTundra:           {R: 0xb8, G: 0xb0, B: 0x88, A: 0xff},
+	terrain.BrushFlat:            {R: 0x9c, G: 0xb0, B: 0x5c, A: 0xff},
+	terrain.BrushHills:           {R: 0x84, G: 0x98, B: 0x4c, A: 0xff},
+	terrain.ConiferHills:         {R: 0x2c, G: 0x6c, B: 0x3c, A: 0xff},
+	terrain.Deciduous:            {R: 0x3c, G: 0x8c, B: 0x3c, A: 0xff},
+	terrain.DeciduousHills:       {R: 0x34, G: 0x78, B: 0x34, A: 0xff},
+	terrain.Desert:               {R: 0xe8, G: 0xd0, B: 0x8c, A: 0xff},
+	terrain.GrassyHills:          {R: 0x8c, G: 0xc0, B: 0x5c, A: 0xff},
+	terrain.HighSnowyMountains:   {R: 0xf8, G: 0xf8, B: 0xff, A: 0xff},
+	terrain.Jungle:               {R: 0x1c, G: 0x6c, B: 0x2c, A: 0xff},
+	terrain.JungleHills:          {R: 0x18, G: 0x5c, B: 0x24, A: 0xff},
+	terrain.Lake:                 {R: 0x5c, G: 0x9c, B: 0xdc, A: 0xff},
+	terrain.LowAridMountains:     {R: 0xa8, G: 0x88, B: 0x60, A: 0xff},
+	terrain.LowConiferMountains:  {R: 0x48, G: 0x70, B: 0x58, A: 0xff},
+	terrain.LowJungleMountains:   {R: 0x38, G: 0x60, B: 0x40, A: 0xff},
+	terrain.LowSnowyMountains:    {R: 0xd8, G: 0xd8, B: 0xe0, A: 0xff},
+	terrain.LowVolcanicMountains: {R: 0x80, G: 0x40, B: 0x30, A: 0xff},
+	terrain.Ocean:                {R: 0x24, G: 0x4c, B: 0x9c, A: 0xff},
+	terrain.PlateauGrassyHills:   {R: 0xa0, G: 0xc8, B: 0x70, A: 0xff},
+	terrain.PolarIce:             {R: 0xe0, G: 0xf0, B: 0xf8, A: 0xff},
+	terrain.Prairie:              {R: 0xc0, G: 0xd8, B: 0x70, A: 0xff},
+	terrain.PrairiePlateau:       {R: 0xb0, G: 0xc8, B: 0x68, A: 0xff},
+	terrain.RockyHills:           {R: 0x98, G: 0x88, B: 0x78, A: 0xff},
+	terrain.SnowyHills:           {R: 0xd0, G: 0xe0, B: 0xe8, A: 0xff},
+	terrain.Swamp:                {R: 0x5c, G: 0x70, B: 0x48, A: 0xff},
+	terrain.Tundra:               {R: 0xa8, G: 0xb8, B: 0xa8, A: 0xff},
+	terrain.UnknownJungleSwamp:   {R: 0x48, G: 0x68, B: 0x40, A: 0xff},
+	terrain.UnknownLand:          {R: 0xa0, G: 0xa0, B: 0x90, A: 0xff},
+	terrain.UnknownMountain:      {R: 0x90, G: 0x90, B: 0x98, A: 0xff},
+	terrain.UnknownWater:         {R: 0x70, G: 0x98, B: 0xc0, A: 0xff},
+}
+
+// terrainColor returns the fill color for the terrain.
+func terrainColor(t terrain.Terrain_e) color.RGBA {
+	if c, ok := TerrainColors[t]; ok {
+		return c
+	}
+	return TerrainColors[terrain.Blank]
+}
+
+// isDark returns true if text drawn on the color should be white.
+func isDark(c color.RGBA) bool {
+	// perceived brightness, using the ITU-R BT.601 weights
+	return 299*int(c.R)+587*int(c.G)+114*int(c.B) < 128*1000
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package render
+
+import (
+	"github.com/playbymail/tribal/tiles"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+)
+
+// ChangeColors is the outline color for each kind of change on a diff map.
+var ChangeColors = map[tiles.Change_e]color.RGBA{
+	tiles.NewTile:             {R: 0x00, G: 0xc0, B: 0x00, A: 0xff},
+	tiles.TerrainRefined:      {R: 0xff, G: 0xe0, B: 0x00, A: 0xff},
+	tiles.NewSettlement:       {R: 0xc0, G: 0x00, B: 0xc0, A: 0xff},
+	tiles.VanishedSettlement:  {R: 0x80, G: 0x00, B: 0x80, A: 0xff},
+	tiles.NewBorder:           {R: 0x00, G: 0xc0, B: 0xe0, A: 0xff},
+	tiles.NewPassage:          {R: 0x00, G: 0xc0, B: 0xe0, A: 0xff},
+	tiles.NewResource:         {R: 0xff, G: 0x90, B: 0x00, A: 0xff},
+	tiles.ForeignUnitAppeared: {R: 0xff, G: 0x00, B: 0x00, A: 0xff},
+	tiles.ForeignUnitVanished: {R: 0x80, G: 0x00, B: 0x00, A: 0xff},
+}
+
+// PNGDiff draws the map with the changed tiles outlined and writes it as a PNG image.
+func PNGDiff(w io.Writer, m *tiles.Map_t, d *tiles.Diff_t, v View_t, opts PNGOptions_t) error {
+	img, err := DiffImage(m, d, v, opts)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// DiffImage draws the map with the changed tiles outlined.
+// The map should be the "to" side of the diff.
+// When a tile has several changes, the outline uses the color of the first.
+func DiffImage(m *tiles.Map_t, d *tiles.Diff_t, v View_t, opts PNGOptions_t) (*image.RGBA, error) {
+	img, err := Image(m, v, opts)
+	if err != nil {
+		return nil, err
+	}
+	g := pngGeometry(opts.HexSize, v)
+	outlined := map[tiles.Point_t]bool{}
+	for _, c := range d.Changes {
+		if outlined[c.Point] || !v.Contains(c.Point) {
+			continue
+		}
+		outlined[c.Point] = true
+		corners := g.corners(c.Point)
+		for i := range corners {
+			drawLine(img, corners[i], corners[(i+1)%6], g.thick*0.75, ChangeColors[c.Kind])
+		}
+	}
+	return img, nil
+}
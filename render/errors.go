@@ -0,0 +1,15 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package render
+
+const (
+	ErrInvalidGrid    = Error("invalid grid")
+	ErrInvalidHexSize = Error("invalid hex size")
+	ErrNoFrames       = Error("no frames")
+)
+
+// Error defines a constant error
+type Error string
+
+// Error implements the Errors interface
+func (e Error) Error() string { return string(e) }
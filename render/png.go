@@ -0,0 +1,277 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package render
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/border"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/passage"
+	"github.com/playbymail/tribal/tiles"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+)
+
+// The PNG map uses flat-top hexes. Even columns are pushed down half a hex,
+// the same as the ASCII map. The corners of a hex are numbered clockwise
+// from the east corner, so edge i runs from corner i to corner i+1.
+var pngEdges = [6]direction.Direction_e{
+	direction.SouthEast,
+	direction.South,
+	direction.SouthWest,
+	direction.NorthWest,
+	direction.North,
+	direction.NorthEast,
+}
+
+var (
+	pngBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	pngOutline    = color.RGBA{R: 0x60, G: 0x60, B: 0x60, A: 0xff}
+	pngUnknown    = color.RGBA{R: 0xd0, G: 0xd0, B: 0xd0, A: 0xff}
+	pngCanal      = color.RGBA{R: 0x20, G: 0xb0, B: 0xb0, A: 0xff}
+	pngRiver      = color.RGBA{R: 0x10, G: 0x40, B: 0xe0, A: 0xff}
+	pngFord       = color.RGBA{R: 0x8c, G: 0x5c, B: 0x2c, A: 0xff}
+	pngPass       = color.RGBA{R: 0x40, G: 0x30, B: 0x20, A: 0xff}
+	pngRoad       = color.RGBA{R: 0x70, G: 0x70, B: 0x70, A: 0xff}
+	pngSettlement = color.RGBA{R: 0x00, G: 0x00, B: 0x00, A: 0xff}
+	pngOurUnit    = color.RGBA{R: 0xe0, G: 0x20, B: 0x20, A: 0xff}
+	pngOtherUnit  = color.RGBA{R: 0xf0, G: 0x90, B: 0x00, A: 0xff}
+	pngResource   = color.RGBA{R: 0xf0, G: 0xd0, B: 0x00, A: 0xff}
+)
+
+// PNGOptions_t controls the PNG renderer.
+type PNGOptions_t struct {
+	HexSize int  // distance from the center of a hex to a corner, in pixels
+	Grid    bool // outline the hexes that we don't know anything about
+}
+
+// PNG draws the tiles in the view and writes them as a PNG image.
+func PNG(w io.Writer, m *tiles.Map_t, v View_t, opts PNGOptions_t) error {
+	img, err := Image(m, v, opts)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// Image draws the tiles in the view.
+func Image(m *tiles.Map_t, v View_t, opts PNGOptions_t) (*image.RGBA, error) {
+	if opts.HexSize < 4 {
+		return nil, fmt.Errorf("hex size %d: %w", opts.HexSize, ErrInvalidHexSize)
+	}
+	g := pngGeometry(opts.HexSize, v)
+	img := image.NewRGBA(image.Rect(0, 0, g.width, g.height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{C: pngBackground}, image.Point{}, draw.Src)
+
+	drawTiles(img, g, m, v, opts, nil)
+
+	return img, nil
+}
+
+// drawTiles draws the tiles in the view. If visible is not nil, only the
+// tiles that it returns true for are drawn.
+//
+// We draw in layers so that the edges and icons aren't covered by a neighbor's fill.
+func drawTiles(img *image.RGBA, g geometry_t, m *tiles.Map_t, v View_t, opts PNGOptions_t, visible func(tiles.Point_t) bool) {
+	for col := v.TopLeft.Column; col <= v.BottomRight.Column; col++ {
+		for row := v.TopLeft.Row; row <= v.BottomRight.Row; row++ {
+			p := tiles.Point_t{Column: col, Row: row}
+			corners := g.corners(p)
+			if t, ok := m.Tiles[p]; ok && (visible == nil || visible(p)) {
+				fillPolygon(img, corners[:], terrainColor(t.Terrain))
+			} else if opts.Grid {
+				fillPolygon(img, corners[:], pngUnknown)
+			} else {
+				continue
+			}
+			for i := range corners {
+				drawLine(img, corners[i], corners[(i+1)%6], 1, pngOutline)
+			}
+		}
+	}
+	for _, t := range m.Sorted() {
+		if !v.Contains(t.Point) || (visible != nil && !visible(t.Point)) {
+			continue
+		}
+		corners := g.corners(t.Point)
+		for i, d := range pngEdges {
+			switch t.Borders[d] {
+			case border.Canal:
+				drawLine(img, corners[i], corners[(i+1)%6], g.thick, pngCanal)
+			case border.River:
+				drawLine(img, corners[i], corners[(i+1)%6], g.thick, pngRiver)
+			}
+		}
+	}
+	for _, t := range m.Sorted() {
+		if !v.Contains(t.Point) || (visible != nil && !visible(t.Point)) {
+			continue
+		}
+		center, corners := g.center(t.Point), g.corners(t.Point)
+		for i, d := range pngEdges {
+			mid := midpoint(corners[i], corners[(i+1)%6])
+			switch t.Passages[d] {
+			case passage.Ford:
+				// a small square on the edge
+				fillPolygon(img, square(mid, g.icon*0.6), pngFord)
+			case passage.Pass:
+				// a small triangle on the edge, pointing up like a mountain
+				fillPolygon(img, []point_t{
+					{mid.x, mid.y - g.icon*0.8},
+					{mid.x + g.icon*0.8, mid.y + g.icon*0.6},
+					{mid.x - g.icon*0.8, mid.y + g.icon*0.6},
+				}, pngPass)
+			case passage.StoneRoad:
+				// a road from the center to the edge
+				drawLine(img, center, mid, g.thick*0.75, pngRoad)
+			}
+		}
+		if t.HexName != nil {
+			fillPolygon(img, square(point_t{center.x, center.y - g.icon*1.5}, g.icon), pngSettlement)
+		}
+		if len(t.Units) != 0 {
+			fillCircle(img, point_t{center.x - g.icon*1.5, center.y + g.icon}, g.icon, pngOurUnit)
+		}
+		for _, id := range t.Encounters {
+			if m.IsForeign(id) {
+				fillCircle(img, point_t{center.x + g.icon*1.5, center.y + g.icon}, g.icon, pngOtherUnit)
+				break
+			}
+		}
+		if len(t.Resources) != 0 {
+			fillPolygon(img, diamond(point_t{center.x, center.y + g.icon*2.2}, g.icon), pngResource)
+		}
+	}
+}
+
+// point_t is a point in image coordinates.
+type point_t struct {
+	x, y float64
+}
+
+// geometry_t converts map points to image coordinates.
+type geometry_t struct {
+	size      float64 // center to corner
+	hexHeight float64 // flat edge to flat edge
+	margin    float64
+	thick     float64 // width of border lines
+	icon      float64 // radius of icons
+	topLeft   tiles.Point_t
+	width     int // image width, in pixels
+	height    int // image height, in pixels
+}
+
+func pngGeometry(size int, v View_t) geometry_t {
+	g := geometry_t{
+		size:      float64(size),
+		hexHeight: math.Sqrt(3) * float64(size),
+		margin:    float64(size) / 2,
+		thick:     math.Max(2, float64(size)/6),
+		icon:      math.Max(2, float64(size)/6),
+		topLeft:   v.TopLeft,
+	}
+	g.width = int(math.Ceil(2*g.margin + 2*g.size + float64(v.Columns()-1)*1.5*g.size))
+	g.height = int(math.Ceil(2*g.margin + (float64(v.Rows())+0.5)*g.hexHeight))
+	return g
+}
+
+// center returns the center of the hex.
+func (g geometry_t) center(p tiles.Point_t) point_t {
+	c := point_t{
+		x: g.margin + g.size + float64(p.Column-g.topLeft.Column)*1.5*g.size,
+		y: g.margin + g.hexHeight/2 + float64(p.Row-g.topLeft.Row)*g.hexHeight,
+	}
+	if p.Column%2 == 0 {
+		c.y += g.hexHeight / 2
+	}
+	return c
+}
+
+// corners returns the corners of the hex, clockwise from the east corner.
+func (g geometry_t) corners(p tiles.Point_t) (corners [6]point_t) {
+	c := g.center(p)
+	for i := range corners {
+		angle := math.Pi / 3 * float64(i)
+		corners[i] = point_t{x: c.x + g.size*math.Cos(angle), y: c.y + g.size*math.Sin(angle)}
+	}
+	return corners
+}
+
+func midpoint(a, b point_t) point_t {
+	return point_t{x: (a.x + b.x) / 2, y: (a.y + b.y) / 2}
+}
+
+func square(c point_t, r float64) []point_t {
+	return []point_t{{c.x - r, c.y - r}, {c.x + r, c.y - r}, {c.x + r, c.y + r}, {c.x - r, c.y + r}}
+}
+
+func diamond(c point_t, r float64) []point_t {
+	return []point_t{{c.x, c.y - r}, {c.x + r, c.y}, {c.x, c.y + r}, {c.x - r, c.y}}
+}
+
+// fillPolygon fills the polygon using the even-odd rule.
+// Pixels are sampled at their centers.
+func fillPolygon(img *image.RGBA, poly []point_t, c color.RGBA) {
+	minY, maxY := poly[0].y, poly[0].y
+	for _, p := range poly {
+		minY, maxY = math.Min(minY, p.y), math.Max(maxY, p.y)
+	}
+	bounds := img.Bounds()
+	for y := int(math.Floor(minY)); y <= int(math.Ceil(maxY)); y++ {
+		if y < bounds.Min.Y || y >= bounds.Max.Y {
+			continue
+		}
+		sy := float64(y) + 0.5
+		// find where the scan line crosses the edges of the polygon
+		var xs []float64
+		for i := range poly {
+			a, b := poly[i], poly[(i+1)%len(poly)]
+			if (a.y <= sy) == (b.y <= sy) {
+				continue
+			}
+			xs = append(xs, a.x+(sy-a.y)*(b.x-a.x)/(b.y-a.y))
+		}
+		for i := 1; i < len(xs); i++ {
+			for j := i; j > 0 && xs[j] < xs[j-1]; j-- {
+				xs[j], xs[j-1] = xs[j-1], xs[j]
+			}
+		}
+		for i := 0; i+1 < len(xs); i += 2 {
+			for x := int(math.Ceil(xs[i] - 0.5)); float64(x)+0.5 <= xs[i+1]; x++ {
+				if bounds.Min.X <= x && x < bounds.Max.X {
+					img.SetRGBA(x, y, c)
+				}
+			}
+		}
+	}
+}
+
+// drawLine draws a line with the given width and square ends.
+func drawLine(img *image.RGBA, a, b point_t, width float64, c color.RGBA) {
+	dx, dy := b.x-a.x, b.y-a.y
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	// offset perpendicular to the line by half the width
+	nx, ny := -dy/length*width/2, dx/length*width/2
+	fillPolygon(img, []point_t{{a.x + nx, a.y + ny}, {b.x + nx, b.y + ny}, {b.x - nx, b.y - ny}, {a.x - nx, a.y - ny}}, c)
+}
+
+// fillCircle fills the circle with the given center and radius.
+func fillCircle(img *image.RGBA, center point_t, r float64, c color.RGBA) {
+	bounds := img.Bounds()
+	for y := int(math.Floor(center.y - r)); y <= int(math.Ceil(center.y+r)); y++ {
+		for x := int(math.Floor(center.x - r)); x <= int(math.Ceil(center.x+r)); x++ {
+			if !(image.Point{X: x, Y: y}).In(bounds) {
+				continue
+			} else if math.Hypot(float64(x)+0.5-center.x, float64(y)+0.5-center.y) <= r {
+				img.SetRGBA(x, y, c)
+			}
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package render
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/tiles"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"io"
+)
+
+var (
+	timelineNewTile  = color.RGBA{R: 0xff, G: 0xe0, B: 0x00, A: 0xff}
+	timelineTrack    = color.RGBA{R: 0xa0, G: 0x00, B: 0x40, A: 0xff}
+	timelineProgress = color.RGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xff}
+)
+
+// TimelineOptions_t controls the timeline renderer.
+type TimelineOptions_t struct {
+	PNGOptions_t
+	Delay   int  // delay between frames, in hundredths of a second
+	PerStep bool // one frame for every step that a unit takes instead of one per turn
+}
+
+// GIF replays the exploration of the map as an animated GIF.
+func GIF(w io.Writer, units []*ast.Unit_t, v View_t, opts TimelineOptions_t) error {
+	frames, err := Timeline(units, v, opts)
+	if err != nil {
+		return err
+	} else if len(frames) == 0 {
+		return ErrNoFrames
+	}
+	anim := &gif.GIF{}
+	for n, frame := range frames {
+		delay := opts.Delay
+		if n == len(frames)-1 {
+			// linger on the final map before starting over
+			delay *= 3
+		}
+		anim.Image = append(anim.Image, paletted(frame))
+		anim.Delay = append(anim.Delay, delay)
+	}
+	return gif.EncodeAll(w, anim)
+}
+
+// Timeline returns the frames that replay the exploration of the map.
+//
+// Each turn gets a frame showing the map as it was at the end of that turn.
+// Tiles that were first seen during the turn are outlined, and the steps
+// that units took during the turn are drawn as tracks. With PerStep set,
+// the tracks are drawn one step at a time, revealing the tiles as they are
+// entered, before the frame for the end of the turn.
+//
+// A bar along the bottom of each frame shows how far along the timeline it is.
+func Timeline(units []*ast.Unit_t, v View_t, opts TimelineOptions_t) ([]*image.RGBA, error) {
+	if opts.HexSize < 4 {
+		return nil, fmt.Errorf("hex size %d: %w", opts.HexSize, ErrInvalidHexSize)
+	}
+	g := pngGeometry(opts.HexSize, v)
+	barHeight := max(4, opts.HexSize/4)
+
+	type frame_t struct {
+		turn    ast.TurnId_t
+		m       *tiles.Map_t
+		tracks  []tiles.Track_t
+		visible func(tiles.Point_t) bool
+	}
+	var list []frame_t
+	prev := tiles.New()
+	for _, turn := range tiles.Turns(units) {
+		m, tracks := tiles.BuildThrough(units, turn), tiles.Tracks(units, turn)
+		if opts.PerStep {
+			revealed := map[tiles.Point_t]bool{}
+			for n, track := range tracks {
+				revealed[track.From], revealed[track.To] = true, true
+				known, seen := prev.Tiles, copyPoints(revealed)
+				list = append(list, frame_t{turn: turn, m: m, tracks: tracks[:n+1], visible: func(p tiles.Point_t) bool {
+					_, ok := known[p]
+					return ok || seen[p]
+				}})
+			}
+		}
+		list = append(list, frame_t{turn: turn, m: m, tracks: tracks})
+		prev = m
+	}
+
+	var frames []*image.RGBA
+	for n, f := range list {
+		img := image.NewRGBA(image.Rect(0, 0, g.width, g.height+barHeight))
+		draw.Draw(img, img.Bounds(), &image.Uniform{C: pngBackground}, image.Point{}, draw.Src)
+		drawTiles(img, g, f.m, v, opts.PNGOptions_t, f.visible)
+
+		// outline the tiles that were first seen this turn
+		for _, t := range f.m.Sorted() {
+			if t.FirstSeen != f.turn || !v.Contains(t.Point) || (f.visible != nil && !f.visible(t.Point)) {
+				continue
+			}
+			corners := g.corners(t.Point)
+			for i := range corners {
+				drawLine(img, corners[i], corners[(i+1)%6], g.thick/2, timelineNewTile)
+			}
+		}
+
+		for _, track := range f.tracks {
+			if !v.Contains(track.From) && !v.Contains(track.To) {
+				continue
+			}
+			from, to := g.center(track.From), g.center(track.To)
+			drawLine(img, from, to, g.thick/2, timelineTrack)
+			fillCircle(img, to, g.thick/2, timelineTrack)
+		}
+
+		width := g.width
+		if len(list) > 1 {
+			width = g.width * n / (len(list) - 1)
+		}
+		draw.Draw(img, image.Rect(0, g.height, width, g.height+barHeight), &image.Uniform{C: timelineProgress}, image.Point{}, draw.Src)
+
+		frames = append(frames, img)
+	}
+	return frames, nil
+}
+
+func copyPoints(set map[tiles.Point_t]bool) map[tiles.Point_t]bool {
+	cp := make(map[tiles.Point_t]bool, len(set))
+	for p := range set {
+		cp[p] = true
+	}
+	return cp
+}
+
+// paletted converts the frame to a paletted image for the GIF encoder.
+// The maps use a small number of flat colors, so we build the palette from
+// the colors in the frame. If there are too many, we fall back to Plan9.
+func paletted(img *image.RGBA) *image.Paletted {
+	p := framePalette(img)
+	if p == nil {
+		p = palette.Plan9
+	}
+	pm := image.NewPaletted(img.Rect, p)
+	draw.Draw(pm, pm.Rect, img, img.Rect.Min, draw.Src)
+	return pm
+}
+
+// framePalette returns the colors in the frame, or nil if there are more than 256.
+func framePalette(img *image.RGBA) color.Palette {
+	var p color.Palette
+	seen := map[color.RGBA]bool{}
+	for y := img.Rect.Min.Y; y < img.Rect.Max.Y; y++ {
+		for x := img.Rect.Min.X; x < img.Rect.Max.X; x++ {
+			c := img.RGBAAt(x, y)
+			if seen[c] {
+				continue
+			} else if len(seen) == 256 {
+				return nil
+			}
+			seen[c] = true
+			p = append(p, c)
+		}
+	}
+	return p
+}
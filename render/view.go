@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package render draws the tile model as text or images.
+package render
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/tiles"
+	"strings"
+)
+
+// View_t is the rectangle of tiles to draw, in absolute columns and rows.
+// The rectangle includes both corners.
+type View_t struct {
+	TopLeft     tiles.Point_t
+	BottomRight tiles.Point_t
+}
+
+// Columns returns the number of columns in the view.
+func (v View_t) Columns() int {
+	return v.BottomRight.Column - v.TopLeft.Column + 1
+}
+
+// Rows returns the number of rows in the view.
+func (v View_t) Rows() int {
+	return v.BottomRight.Row - v.TopLeft.Row + 1
+}
+
+// Contains returns true if the point is in the view.
+func (v View_t) Contains(p tiles.Point_t) bool {
+	return v.TopLeft.Column <= p.Column && p.Column <= v.BottomRight.Column &&
+		v.TopLeft.Row <= p.Row && p.Row <= v.BottomRight.Row
+}
+
+// Pan returns the view moved by the given number of columns and rows.
+// The view stops at the edges of the big map.
+func (v View_t) Pan(columns, rows int) View_t {
+	maxColumn, maxRow := tiles.Grids*tiles.GridColumns, tiles.Grids*tiles.GridRows
+	if v.TopLeft.Column+columns < 1 {
+		columns = 1 - v.TopLeft.Column
+	} else if v.BottomRight.Column+columns > maxColumn {
+		columns = maxColumn - v.BottomRight.Column
+	}
+	if v.TopLeft.Row+rows < 1 {
+		rows = 1 - v.TopLeft.Row
+	} else if v.BottomRight.Row+rows > maxRow {
+		rows = maxRow - v.BottomRight.Row
+	}
+	v.TopLeft.Column, v.TopLeft.Row = v.TopLeft.Column+columns, v.TopLeft.Row+rows
+	v.BottomRight.Column, v.BottomRight.Row = v.BottomRight.Column+columns, v.BottomRight.Row+rows
+	return v
+}
+
+// ViewAround returns a view of the given size centered on the point.
+func ViewAround(center tiles.Point_t, columns, rows int) View_t {
+	v := View_t{
+		TopLeft:     tiles.Point_t{Column: center.Column - columns/2, Row: center.Row - rows/2},
+		BottomRight: tiles.Point_t{Column: center.Column - columns/2 + columns - 1, Row: center.Row - rows/2 + rows - 1},
+	}
+	// pan by zero to clamp the view to the big map
+	return v.Pan(0, 0)
+}
+
+// ViewOfGrids returns a view that covers the grids from the top left grid
+// to the bottom right grid. Grids are named the same as in coordinates
+// (e.g. "KP"). Pass the same grid twice to view a single grid.
+func ViewOfGrids(from, to string) (View_t, error) {
+	fr, fc, err := gridToIndex(from)
+	if err != nil {
+		return View_t{}, err
+	}
+	tr, tc, err := gridToIndex(to)
+	if err != nil {
+		return View_t{}, err
+	}
+	if tr < fr {
+		fr, tr = tr, fr
+	}
+	if tc < fc {
+		fc, tc = tc, fc
+	}
+	return View_t{
+		TopLeft:     tiles.Point_t{Column: fc*tiles.GridColumns + 1, Row: fr*tiles.GridRows + 1},
+		BottomRight: tiles.Point_t{Column: (tc + 1) * tiles.GridColumns, Row: (tr + 1) * tiles.GridRows},
+	}, nil
+}
+
+// ViewOfMap returns a view that covers every tile in the map, plus a margin.
+// Returns false if the map is empty.
+func ViewOfMap(m *tiles.Map_t, margin int) (View_t, bool) {
+	tl, br, ok := m.Bounds()
+	if !ok {
+		return View_t{}, false
+	}
+	v := View_t{
+		TopLeft:     tiles.Point_t{Column: max(tl.Column-margin, 1), Row: max(tl.Row-margin, 1)},
+		BottomRight: tiles.Point_t{Column: min(br.Column+margin, tiles.Grids*tiles.GridColumns), Row: min(br.Row+margin, tiles.Grids*tiles.GridRows)},
+	}
+	return v, true
+}
+
+// gridToIndex converts a grid name to 0-based row and column indexes.
+func gridToIndex(grid string) (row, column int, err error) {
+	grid = strings.ToUpper(strings.TrimSpace(grid))
+	if len(grid) != 2 || !('A' <= grid[0] && grid[0] <= 'Z') || !('A' <= grid[1] && grid[1] <= 'Z') {
+		return 0, 0, fmt.Errorf("grid %q: %w", grid, ErrInvalidGrid)
+	}
+	return int(grid[0] - 'A'), int(grid[1] - 'A'), nil
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package rtf
+
+// Error defines a constant error
+type Error string
+
+// Error implements the Errors interface
+func (e Error) Error() string { return string(e) }
+
+const (
+	ErrInvalidDocument Error = "invalid document"
+	ErrNotADocument    Error = "not a document"
+)
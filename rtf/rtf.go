@@ -0,0 +1,248 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package rtf provides a reader for Rich Text Format documents.
+//
+// The reader only extracts the text of the document. Formatting is ignored,
+// as are destinations like the font table, pictures and headers.
+package rtf
+
+import (
+	"bytes"
+	"github.com/playbymail/tribal/text"
+	"os"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ReadFile loads an RTF document from a file and returns the text as a slice of byte slices.
+// Each slice of bytes represents a paragraph in the original document.
+func ReadFile(path string) ([][]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Read(data)
+}
+
+// Read reads an RTF document from a byte slice and returns the contents as a slice of byte slices.
+// Each slice of bytes is a single paragraph from the document.
+// Line breaks inside a paragraph start a new slice, like they would in a text file.
+func Read(data []byte) ([][]byte, error) {
+	if !bytes.HasPrefix(data, []byte(`{\rtf`)) {
+		return nil, ErrNotADocument
+	}
+
+	// group_t is the state that is saved when a group starts and restored when it ends.
+	type group_t struct {
+		skip bool // true if the group is a destination that we ignore
+		uc   int  // number of fallback characters after a \u control word
+	}
+	stack := []group_t{{uc: 1}}
+	state := &stack[0]
+
+	var paragraphs [][]byte
+	var para []byte
+	var surrogate rune // high surrogate waiting for its pair
+	fallback := 0      // fallback characters left to skip after a \u control word
+	emit := func(r rune) {
+		if fallback > 0 {
+			fallback--
+			return
+		} else if state.skip {
+			return
+		}
+		para = utf8.AppendRune(para, r)
+	}
+	endParagraph := func() {
+		if !state.skip {
+			paragraphs = append(paragraphs, para)
+			para = nil
+		}
+	}
+
+	for pos := 0; pos < len(data); {
+		ch := data[pos]
+		switch ch {
+		case '{':
+			pos++
+			stack = append(stack, *state)
+			state = &stack[len(stack)-1]
+			fallback = 0
+			// an ignorable destination starts with \*
+			if bytes.HasPrefix(data[pos:], []byte(`\*`)) {
+				state.skip = true
+			}
+		case '}':
+			pos++
+			if len(stack) == 1 {
+				return nil, ErrInvalidDocument
+			}
+			stack = stack[:len(stack)-1]
+			state = &stack[len(stack)-1]
+			fallback = 0
+		case '\r', '\n':
+			// line endings in the source are not part of the text
+			pos++
+		case '\\':
+			pos++
+			if pos == len(data) {
+				return nil, ErrInvalidDocument
+			}
+			if !isLetter(data[pos]) {
+				// control symbol
+				sym := data[pos]
+				pos++
+				switch sym {
+				case '\'':
+					if pos+2 > len(data) {
+						return nil, ErrInvalidDocument
+					}
+					b, err := strconv.ParseUint(string(data[pos:pos+2]), 16, 8)
+					if err != nil {
+						return nil, ErrInvalidDocument
+					}
+					pos += 2
+					emit(text.DecodeWindows1252(byte(b)))
+				case '~':
+					emit(' ')
+				case '_':
+					emit('-')
+				case '-':
+					// optional hyphen
+				case '*':
+					state.skip = true
+				case '\r', '\n':
+					if fallback == 0 {
+						endParagraph()
+					}
+				default:
+					emit(rune(sym))
+				}
+				continue
+			}
+			// control word, with an optional numeric parameter
+			start := pos
+			for pos < len(data) && isLetter(data[pos]) {
+				pos++
+			}
+			word := string(data[start:pos])
+			param, hasParam := 0, false
+			if pos < len(data) && (data[pos] == '-' || isDigit(data[pos])) {
+				start = pos
+				pos++
+				for pos < len(data) && isDigit(data[pos]) {
+					pos++
+				}
+				if n, err := strconv.Atoi(string(data[start:pos])); err == nil {
+					param, hasParam = n, true
+				}
+			}
+			// a single space after a control word is part of the word
+			if pos < len(data) && data[pos] == ' ' {
+				pos++
+			}
+
+			switch word {
+			case "bin":
+				// binary data is never text
+				if hasParam && param > 0 {
+					pos = min(pos+param, len(data))
+				}
+			case "u":
+				r := rune(param)
+				if r < 0 {
+					r += 0x10000
+				}
+				fallback = 0
+				if utf16.IsSurrogate(r) && r < 0xDC00 {
+					surrogate = r
+				} else if surrogate != 0 {
+					emit(utf16.DecodeRune(surrogate, r))
+					surrogate = 0
+				} else {
+					emit(r)
+				}
+				fallback = state.uc
+			case "uc":
+				if hasParam && param >= 0 {
+					state.uc = param
+				}
+			case "par", "line", "sect", "page":
+				endParagraph()
+			case "tab":
+				emit('\t')
+			case "emdash":
+				emit('—')
+			case "endash":
+				emit('–')
+			case "emspace", "enspace", "qmspace":
+				emit(' ')
+			case "bullet":
+				emit('•')
+			case "lquote":
+				emit('‘')
+			case "rquote":
+				emit('’')
+			case "ldblquote":
+				emit('“')
+			case "rdblquote":
+				emit('”')
+			default:
+				if skippedDestinations[word] {
+					state.skip = true
+				}
+			}
+		default:
+			// 8-bit characters should be escaped, but some writers don't bother
+			pos++
+			emit(text.DecodeWindows1252(ch))
+		}
+	}
+	if len(stack) != 1 {
+		return nil, ErrInvalidDocument
+	}
+	if len(para) != 0 {
+		paragraphs = append(paragraphs, para)
+	}
+	return paragraphs, nil
+}
+
+// skippedDestinations are the destinations that don't hold document text.
+var skippedDestinations = map[string]bool{
+	"author":     true,
+	"colortbl":   true,
+	"comment":    true,
+	"doccomm":    true,
+	"filetbl":    true,
+	"fldinst":    true,
+	"fonttbl":    true,
+	"footer":     true,
+	"footerf":    true,
+	"footerl":    true,
+	"footerr":    true,
+	"footnote":   true,
+	"header":     true,
+	"headerf":    true,
+	"headerl":    true,
+	"headerr":    true,
+	"info":       true,
+	"keywords":   true,
+	"listtable":  true,
+	"object":     true,
+	"operator":   true,
+	"pict":       true,
+	"rsidtbl":    true,
+	"stylesheet": true,
+	"subject":    true,
+	"title":      true,
+	"xmlnstbl":   true,
+}
+
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
+
+func isLetter(ch byte) bool {
+	return ('a' <= ch && ch <= 'z') || ('A' <= ch && ch <= 'Z')
+}
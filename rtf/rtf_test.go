@@ -0,0 +1,51 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package rtf_test
+
+import (
+	"errors"
+	"github.com/playbymail/tribal/docx"
+	"github.com/playbymail/tribal/rtf"
+	"testing"
+)
+
+func TestRead(t *testing.T) {
+	const input = `{\rtf1\ansi\ansicpg1252\deff0{\fonttbl{\f0\fnil Calibri;}}{\colortbl ;\red0\green0\blue0;}
+{\*\generator Riched20 10.0.19041}\viewkind4\uc1
+\pard\f0\fs22 Tribe 0987, , Current Hex = KP 0608\par
+Current Turn 900-05 (#5), {\b Summer}, FINE\par
+Caf\'e9 \ldblquote Los Angeles\rdblquote\line Tribe Movement: Move N\emdash PR\par
+Unicode \u8364?\u-10179?\u-8704?  \{braces\}\par
+{\field{\*\fldinst{HYPERLINK "x"}}{\fldrslt Scout 1}}\tab done\par
+}`
+	want := []string{
+		"Tribe 0987, , Current Hex = KP 0608",
+		"Current Turn 900-05 (#5), Summer, FINE",
+		"Café “Los Angeles”",
+		"Tribe Movement: Move N—PR",
+		"Unicode €\U0001F600  {braces}",
+		"Scout 1\tdone",
+	}
+
+	if got := docx.DetectWordDocType([]byte(input)); got != docx.Rtf {
+		t.Errorf("DetectWordDocType: want %v, got %v", docx.Rtf, got)
+	}
+	got, err := rtf.Read([]byte(input))
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	} else if len(got) != len(want) {
+		t.Fatalf("Read: want %d paragraphs, got %d: %q", len(want), len(got), got)
+	}
+	for i := range want {
+		if string(got[i]) != want[i] {
+			t.Errorf("paragraph %d: want %q, got %q", i+1, want[i], got[i])
+		}
+	}
+
+	if _, err := rtf.Read([]byte("plain text")); !errors.Is(err, rtf.ErrNotADocument) {
+		t.Errorf("plain text: want %v, got %v", rtf.ErrNotADocument, err)
+	}
+	if _, err := rtf.Read([]byte(`{\rtf1 unbalanced`)); !errors.Is(err, rtf.ErrInvalidDocument) {
+		t.Errorf("unbalanced: want %v, got %v", rtf.ErrInvalidDocument, err)
+	}
+}
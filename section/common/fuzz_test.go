@@ -0,0 +1,109 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package common_test
+
+import (
+	"bytes"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/norm"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/section/common"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var (
+	fuzzTurn  = &ast.Turn_t{Id: 5, Year: 900, Month: 5}
+	fuzzStart = ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8}
+)
+
+// seedCorpus adds every line from the section regression corpus to the fuzzer.
+// the lines are normalized the same way that section.Parser.Split normalizes them.
+func seedCorpus(f *testing.F, prefix string, normalize func([]byte) []byte) {
+	paths, err := filepath.Glob(filepath.Join("..", "testdata", "*.report.txt"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+		data = norm.LineEndings(norm.NormalizeCase(norm.NormalizeSpaces(data)))
+		for _, line := range bytes.Split(data, []byte{'\n'}) {
+			if bytes.HasPrefix(line, []byte(prefix)) {
+				f.Add(normalize(line))
+			}
+		}
+	}
+}
+
+func FuzzParseScoutMovement(f *testing.F) {
+	seedCorpus(f, "scout ", norm.ScoutMovement)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		log.SetOutput(io.Discard)
+		list, err := common.ParseScoutMovement(fuzzTurn, "0987", fuzzStart, input)
+		if err != nil {
+			return
+		}
+		from := fuzzStart
+		for _, p := range list {
+			if p.From != from {
+				t.Errorf("patrol: from %s: want %s", p.From, from)
+			}
+			checkStep(t, p.From, p.Direction, p.To)
+			from = p.To
+		}
+	})
+}
+
+func FuzzParseTribeMovement(f *testing.F) {
+	seedCorpus(f, "tribe movement:", norm.TribeMovement)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		log.SetOutput(io.Discard)
+		list, err := common.ParseTribeMovement(fuzzTurn, "0987", fuzzStart, input)
+		if err != nil {
+			return
+		}
+		from := fuzzStart
+		for _, m := range list {
+			if m.From != from {
+				t.Errorf("march: from %s: want %s", m.From, from)
+			}
+			checkStep(t, m.From, m.Direction, m.To)
+			from = m.To
+		}
+	})
+}
+
+func FuzzParseUnitStatus(f *testing.F) {
+	seedCorpus(f, "0987", norm.UnitStatus)
+	seedCorpus(f, "3987", norm.UnitStatus)
+	f.Fuzz(func(t *testing.T, input []byte) {
+		log.SetOutput(io.Discard)
+		s, err := common.ParseUnitStatus(fuzzTurn, fuzzStart, input)
+		if err != nil {
+			return
+		}
+		if s.Tile.Coordinates != fuzzStart {
+			t.Errorf("status: tile %s: want %s", s.Tile.Coordinates, fuzzStart)
+		}
+		if !bytes.HasPrefix(input, []byte(s.Unit)) {
+			t.Errorf("status: unit %q: not a prefix of %q", s.Unit, input)
+		}
+	})
+}
+
+// checkStep verifies that the destination of a step is reachable from the origin.
+func checkStep(t *testing.T, from ast.Coordinates_t, d direction.Direction_e, to ast.Coordinates_t) {
+	if d == direction.None {
+		if to != from {
+			t.Errorf("step: from %s: no direction: to %s", from, to)
+		}
+	} else if want := from.Move(d); to != want {
+		t.Errorf("step: from %s: %s: to %s: want %s", from, d, to, want)
+	}
+}
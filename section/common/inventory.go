@@ -0,0 +1,173 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package common
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/is"
+	"github.com/playbymail/tribal/item"
+	"github.com/playbymail/tribal/parser/ast"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// reInventoryToken matches a quantity (with optional thousands separators) or a word.
+	reInventoryToken = regexp.MustCompile(`\d{1,3}(?:,\d{3})+|\d+|[a-z][a-z']*`)
+)
+
+// ParseInventory parses the people and goods blocks of a unit.
+// The blocks are optional and follow the status line.
+//
+// Each block starts with a heading line, like "humans" or "finished items".
+// The lines in a block are either a list of names and quantities,
+//
+//	cattle 300,horses 1,250
+//	300 cattle,1,250 horses
+//
+// or a line of names followed by a line with a quantity for each name.
+//
+//	people warriors actives inactives
+//	6,160 1,500 1,500 3,160
+//
+// Names that we don't recognize are saved as excess input.
+func ParseInventory(turn *ast.Turn_t, unitId ast.UnitId_t, lines [][]byte) (*ast.Inventory_t, error) {
+	if len(lines) == 0 {
+		return nil, ast.ErrNoMatch
+	}
+	inv := &ast.Inventory_t{
+		Turn: turn,
+		Unit: unitId,
+	}
+	excess := func(format string, args ...any) {
+		if inv.Errors == nil {
+			inv.Errors = &ast.InventoryErrors_t{}
+		}
+		inv.Errors.ExcessInput = append(inv.Errors.ExcessInput, fmt.Sprintf(format, args...))
+	}
+
+	// names from a line of column headings, waiting for a line of quantities
+	var columns []string
+	for _, line := range lines {
+		if is.InventoryHeading(line) {
+			columns = nil
+			continue
+		}
+		tokens := reInventoryToken.FindAllString(string(line), -1)
+		names, quantities := 0, 0
+		for _, token := range tokens {
+			if isQuantity(token) {
+				quantities++
+			} else {
+				names++
+			}
+		}
+		if quantities == 0 {
+			// a line of column headings
+			if columns != nil {
+				excess("%s", strings.Join(columns, " "))
+			}
+			columns = tokens
+			continue
+		} else if names == 0 && columns != nil {
+			// a line of quantities for the column headings
+			if len(tokens) != len(columns) {
+				excess("%s: %s", strings.Join(columns, " "), line)
+			} else {
+				for i, name := range columns {
+					if !addToInventory(inv, name, toQuantity(tokens[i])) {
+						excess("%s %s", name, tokens[i])
+					}
+				}
+			}
+			columns = nil
+			continue
+		} else if columns != nil {
+			excess("%s", strings.Join(columns, " "))
+			columns = nil
+		}
+
+		// a list of names and quantities. the first token tells us which comes first.
+		quantityFirst := isQuantity(tokens[0])
+		var name []string
+		var qty string
+		pair := func() {
+			if qty == "" && len(name) == 0 {
+				// nothing to pair
+			} else if qty == "" || len(name) == 0 || !addToInventory(inv, strings.Join(name, " "), toQuantity(qty)) {
+				if quantityFirst {
+					excess("%s", strings.TrimSpace(qty+" "+strings.Join(name, " ")))
+				} else {
+					excess("%s", strings.TrimSpace(strings.Join(name, " ")+" "+qty))
+				}
+			}
+			name, qty = nil, ""
+		}
+		for _, token := range tokens {
+			if !isQuantity(token) {
+				name = append(name, token)
+			} else if quantityFirst {
+				// the quantity starts a new pair
+				pair()
+				qty = token
+			} else {
+				// the quantity ends the pair
+				qty = token
+				pair()
+			}
+		}
+		pair()
+	}
+	if columns != nil {
+		excess("%s", strings.Join(columns, " "))
+	}
+
+	return inv, nil
+}
+
+// addToInventory adds the quantity to the population or the item.
+// Returns false if the name isn't a population or item name.
+func addToInventory(inv *ast.Inventory_t, name string, qty int) bool {
+	switch name {
+	case "people", "warriors", "actives", "inactives":
+		if inv.Population == nil {
+			inv.Population = &ast.Population_t{}
+		}
+		switch name {
+		case "people":
+			inv.Population.People += qty
+		case "warriors":
+			inv.Population.Warriors += qty
+		case "actives":
+			inv.Population.Actives += qty
+		case "inactives":
+			inv.Population.Inactives += qty
+		}
+		return true
+	}
+	enum, ok := item.LowerCaseName[name]
+	if !ok {
+		// some names are split into words, like "mill stone"
+		if enum, ok = item.LowerCaseName[strings.ReplaceAll(name, " ", "")]; !ok {
+			return false
+		}
+	}
+	for i := range inv.Items {
+		if inv.Items[i].Item == enum {
+			inv.Items[i].Quantity += qty
+			return true
+		}
+	}
+	inv.Items = append(inv.Items, ast.Item_t{Item: enum, Quantity: qty})
+	return true
+}
+
+func isQuantity(token string) bool {
+	return token != "" && '0' <= token[0] && token[0] <= '9'
+}
+
+func toQuantity(token string) int {
+	n, _ := strconv.Atoi(strings.ReplaceAll(token, ",", ""))
+	return n
+}
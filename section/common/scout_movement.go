@@ -51,7 +51,6 @@ func ParseScoutMovement(turn *ast.Turn_t, id ast.UnitId_t, start ast.Coordinates
 
 	segments = segments[1:]                       // accept the first segment
 	from, previousTerrain := start, terrain.Blank // assign the starting location
-	_ = previousTerrain
 
 	// big loop should process all the things, unfortunately
 	//if turn == 19 && id == "0163" && patrolId == 1 {
@@ -63,6 +62,8 @@ func ParseScoutMovement(turn *ast.Turn_t, id ast.UnitId_t, start ast.Coordinates
 		//}
 		if ps, ok := acceptPatrolSuccess(turn, id, patrolId, from, seg); ok {
 			list = append(list, ps)
+			// the next step starts where this one ended
+			from, previousTerrain = ps.To, ps.Terrain
 		} else if ps, ok := acceptPatrolFailure(turn, id, patrolId, from, previousTerrain, seg); ok {
 			list = append(list, ps)
 		} else if ps, ok := acceptPatrolFound(turn, id, patrolId, from, previousTerrain, seg); ok {
@@ -103,6 +104,7 @@ func acceptPatrolFailure(turn *ast.Turn_t, id ast.UnitId_t, patrolId int, from a
 					Direction: direction.None,
 					To:        from,
 					Terrain:   fromTerrain,
+					Failure:   ast.CantMoveOnWater,
 					Neighbors: []*ast.Neighbor_t{
 						{Terrain: ter, Direction: []direction.Direction_e{dir}},
 					},
@@ -120,6 +122,7 @@ func acceptPatrolFailure(turn *ast.Turn_t, id ast.UnitId_t, patrolId int, from a
 					Direction: direction.None,
 					To:        from,
 					Terrain:   fromTerrain,
+					Failure:   ast.NoFord,
 					Borders: []*ast.Border_t{
 						{Border: bor, Direction: []direction.Direction_e{dir}},
 					},
@@ -137,6 +140,7 @@ func acceptPatrolFailure(turn *ast.Turn_t, id ast.UnitId_t, patrolId int, from a
 					Direction: direction.None,
 					To:        from,
 					Terrain:   fromTerrain,
+					Failure:   ast.NotEnoughMPs,
 					Neighbors: []*ast.Neighbor_t{
 						{Terrain: ter, Direction: []direction.Direction_e{dir}},
 					},
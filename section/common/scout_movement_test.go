@@ -0,0 +1,32 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package common_test
+
+import (
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/section/common"
+	"testing"
+)
+
+// TestParseScoutMovementSteps verifies that each step of a patrol starts
+// where the previous step ended.
+func TestParseScoutMovementSteps(t *testing.T) {
+	turn := &ast.Turn_t{Id: 5, Year: 900, Month: 5}
+	start := ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8}
+	list, err := common.ParseScoutMovement(turn, "0987", start, []byte(`scout 1:scout\n-pr\ne-pr\se-pr`))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	} else if len(list) != 3 {
+		t.Fatalf("parse: want 3 steps, got %d", len(list))
+	}
+	from := start
+	for i, p := range list {
+		if p.From != from {
+			t.Errorf("step %d: from: want %s, got %s", i+1, from, p.From)
+		}
+		if want := p.From.Move(p.Direction); p.To != want {
+			t.Errorf("step %d: to: want %s, got %s", i+1, want, p.To)
+		}
+		from = p.To
+	}
+}
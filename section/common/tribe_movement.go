@@ -88,6 +88,7 @@ func acceptMarchFailure(turn *ast.Turn_t, id ast.UnitId_t, from ast.Coordinates_
 					Direction: direction.None,
 					To:        from,
 					Terrain:   fromTerrain,
+					Failure:   ast.CantMoveOnWater,
 					Neighbors: []*ast.Neighbor_t{
 						{Terrain: ter, Direction: []direction.Direction_e{dir}},
 					},
@@ -103,6 +104,7 @@ func acceptMarchFailure(turn *ast.Turn_t, id ast.UnitId_t, from ast.Coordinates_
 				Direction: direction.None,
 				To:        from,
 				Terrain:   fromTerrain,
+				Failure:   ast.CantMoveWagons,
 				Neighbors: []*ast.Neighbor_t{
 					{Terrain: terrain.UnknownJungleSwamp, Direction: []direction.Direction_e{dir}},
 				},
@@ -118,6 +120,7 @@ func acceptMarchFailure(turn *ast.Turn_t, id ast.UnitId_t, from ast.Coordinates_
 					Direction: direction.None,
 					To:        from,
 					Terrain:   fromTerrain,
+					Failure:   ast.NoFord,
 					Borders: []*ast.Border_t{
 						{Border: bor, Direction: []direction.Direction_e{dir}},
 					},
@@ -134,6 +137,7 @@ func acceptMarchFailure(turn *ast.Turn_t, id ast.UnitId_t, from ast.Coordinates_
 					Direction: direction.None,
 					To:        from,
 					Terrain:   fromTerrain,
+					Failure:   ast.NotEnoughMPs,
 					Neighbors: []*ast.Neighbor_t{
 						{Terrain: ter, Direction: []direction.Direction_e{dir}},
 					},
@@ -0,0 +1,46 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package section
+
+import (
+	"crypto/sha1"
+	"fmt"
+)
+
+// Fingerprint returns the SHA1 hash of the lines captured in the sections.
+//
+// Unlike a hash of the file, the fingerprint doesn't change when a report
+// is re-saved in Word or converted to text, since Split has already
+// normalized case, spaces and line endings and dropped everything that
+// isn't part of a section. Line numbers aren't part of the fingerprint.
+//
+// Returns an empty string if there are no sections.
+func Fingerprint(sections []*Section) string {
+	if len(sections) == 0 {
+		return ""
+	}
+	h := sha1.New()
+	// each line is terminated so that moving text between lines changes the hash
+	write := func(line []byte) {
+		_, _ = h.Write(line)
+		_, _ = h.Write([]byte{'\n'})
+	}
+	for _, s := range sections {
+		write(s.Lines.Unit)
+		write(s.Lines.Turn)
+		write(s.Lines.UnitMoves)
+		write(s.Lines.UnitFollows)
+		write(s.Lines.UnitGoesTo)
+		write(s.Lines.FleetMoves)
+		for _, line := range s.Lines.ScoutLines {
+			write(line)
+		}
+		write(s.Lines.Status)
+		for _, line := range s.Lines.Inventory {
+			write(line)
+		}
+		// an empty line separates the sections
+		write(nil)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
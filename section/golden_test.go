@@ -0,0 +1,95 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package section_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/section"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// implements a regression test for the section splitter and parsers.
+// every report in the testdata corpus is split and parsed, and the
+// resulting units are compared against the checked-in golden files.
+//
+// to update the golden files after an intentional change, run
+//
+//	go test ./section -run TestGolden -update
+
+var update = flag.Bool("update", false, "update golden files")
+
+func TestGolden(t *testing.T) {
+	// the parsers are chatty, so silence the logger
+	log.SetOutput(io.Discard)
+	defer log.SetOutput(os.Stderr)
+
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	} else if len(paths) == 0 {
+		t.Fatal("testdata: no reports found")
+	}
+	for _, path := range paths {
+		name := strings.TrimSuffix(filepath.Base(path), ".report.txt")
+		t.Run(name, func(t *testing.T) {
+			input, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var units []*ast.Unit_t
+			for _, s := range section.New(section.DefaultConfig()).Split(input) {
+				if err := s.Parse(path); err != nil {
+					t.Logf("section %d: %v", s.Id, err)
+				}
+				units = append(units, s.Unit)
+			}
+			got, err := json.MarshalIndent(units, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, '\n')
+
+			golden := filepath.Join("testdata", name+".golden.json")
+			if *update {
+				if err := os.WriteFile(golden, got, 0644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("%v (run with -update to create it)", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s: output does not match golden file\n%s", path, firstDifference(want, got))
+			}
+		})
+	}
+}
+
+// firstDifference returns a short description of the first line that differs.
+func firstDifference(want, got []byte) string {
+	wantLines, gotLines := bytes.Split(want, []byte{'\n'}), bytes.Split(got, []byte{'\n'})
+	for n := 0; n < len(wantLines) || n < len(gotLines); n++ {
+		var w, g []byte
+		if n < len(wantLines) {
+			w = wantLines[n]
+		}
+		if n < len(gotLines) {
+			g = gotLines[n]
+		}
+		if !bytes.Equal(w, g) {
+			return fmt.Sprintf("line %d:\n\twant: %s\n\t got: %s", n+1, w, g)
+		}
+	}
+	return "no difference"
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package section
+
+import (
+	"log"
+	"runtime"
+)
+
+// Config_t selects the kinds of lines that the splitter captures.
+type Config_t struct {
+	SplitTurns   bool
+	SplitFollows bool
+	SplitGoesTo  bool
+	SplitMarches bool
+	SplitSails   bool
+	SplitPatrols bool
+	SplitStatus  bool
+	// SplitInventory captures the people and goods blocks that follow the status line.
+	SplitInventory bool
+}
+
+// DefaultConfig returns a config that captures every kind of line that
+// the parsers support. Fleet movement isn't implemented yet, so it is
+// not captured.
+func DefaultConfig() Config_t {
+	return Config_t{
+		SplitTurns:     true,
+		SplitFollows:   true,
+		SplitGoesTo:    true,
+		SplitMarches:   true,
+		SplitPatrols:   true,
+		SplitStatus:    true,
+		SplitInventory: true,
+	}
+}
+
+// Parser splits and parses turn reports.
+//
+// A Parser doesn't share state with other parsers, so separate parsers may
+// be used from separate goroutines. A single Parser should not be used from
+// more than one goroutine at a time.
+type Parser struct {
+	Config Config_t
+	// Workers is the number of sections to parse at the same time.
+	// If it is less than 1, we use the number of CPUs.
+	Workers int
+	// Log is where the section parsers write their trace.
+	// If it is nil, we use the standard logger.
+	Log *log.Logger
+	// Sink, if set, is called with every section after it is parsed.
+	// Sections are sent in report order, from the goroutine that called Parse.
+	Sink func(s *Section)
+}
+
+// New returns a parser that uses the config.
+func New(cfg Config_t) *Parser {
+	return &Parser{Config: cfg}
+}
+
+// Parse splits the report into sections and parses them on a pool of workers.
+// Returns the sections in report order. Errors are recorded in each section
+// and don't stop the other sections from being parsed.
+func (p *Parser) Parse(path string, input []byte) []*Section {
+	lg := p.Log
+	if lg == nil {
+		lg = log.Default()
+	}
+	workers := p.Workers
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	sections := p.Split(input)
+
+	// each section gets a channel that is closed when it has been parsed,
+	// so that we can send the results to the sink in order.
+	done := make([]chan struct{}, len(sections))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+	jobs := make(chan int)
+	for w := 0; w < workers && w < len(sections); w++ {
+		go func() {
+			for i := range jobs {
+				sections[i].Error = sections[i].parse(path, lg)
+				close(done[i])
+			}
+		}()
+	}
+	go func() {
+		for i := range sections {
+			jobs <- i
+		}
+		close(jobs)
+	}()
+
+	for i, s := range sections {
+		<-done[i]
+		if p.Sink != nil {
+			p.Sink(s)
+		}
+	}
+	return sections
+}
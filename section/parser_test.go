@@ -0,0 +1,100 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package section_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/section"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// TestParserConcurrent verifies that parsing on a pool of workers gives
+// the same units as the golden files, in report order, and that separate
+// parsers can run at the same time.
+func TestParserConcurrent(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for _, path := range paths {
+		input, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want, err := os.ReadFile(strings.TrimSuffix(path, ".report.txt") + ".golden.json")
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var sunk []*ast.Unit_t
+			p := section.New(section.DefaultConfig())
+			p.Workers = 4
+			p.Log = log.New(io.Discard, "", 0)
+			p.Sink = func(s *section.Section) {
+				sunk = append(sunk, s.Unit)
+			}
+			var units []*ast.Unit_t
+			for n, s := range p.Parse(path, input) {
+				if n != s.Id-1 {
+					t.Errorf("%s: section %d: out of order: id %d", path, n+1, s.Id)
+				}
+				units = append(units, s.Unit)
+			}
+			for _, list := range [][]*ast.Unit_t{units, sunk} {
+				got, err := json.MarshalIndent(list, "", "  ")
+				if err != nil {
+					t.Error(err)
+					return
+				} else if got = append(got, '\n'); !bytes.Equal(got, want) {
+					t.Errorf("%s: output does not match golden file\n%s", path, firstDifference(want, got))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// TestFingerprint verifies that the fingerprint ignores the differences that
+// re-saving or converting a report introduces, but not changes to the moves.
+func TestFingerprint(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "scouts.report.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fingerprint := func(input []byte) string {
+		return section.Fingerprint(section.New(section.DefaultConfig()).Split(input))
+	}
+	want := fingerprint(input)
+	if want == "" {
+		t.Fatal("fingerprint: want hash, got empty string")
+	}
+
+	// Word adds blank paragraphs and Windows line endings, and players change case
+	resaved := bytes.ReplaceAll(bytes.ToUpper(input), []byte{'\n'}, []byte("\r\n\r\n"))
+	resaved = bytes.ReplaceAll(resaved, []byte(", "), []byte(",   "))
+	if got := fingerprint(resaved); got != want {
+		t.Errorf("re-saved: want %s, got %s", want, got)
+	}
+
+	edited := bytes.Replace(input, []byte("Scout 1:"), []byte("Scout 2:"), 1)
+	if bytes.Equal(edited, input) {
+		t.Fatal("edited: no scout line to edit")
+	} else if got := fingerprint(edited); got == want {
+		t.Errorf("edited: want new fingerprint, got %s", got)
+	}
+
+	if got := section.Fingerprint(nil); got != "" {
+		t.Errorf("no sections: want empty string, got %q", got)
+	}
+}
@@ -23,6 +23,7 @@ type Section struct {
 	UnitId []byte // taken from the header
 	Lines  struct {
 		FleetMoves  []byte
+		Inventory   [][]byte // people and goods blocks, including the headings
 		Turn        []byte
 		ScoutLines  [][]byte
 		Status      []byte
@@ -31,25 +32,31 @@ type Section struct {
 		UnitGoesTo  []byte
 		UnitMoves   []byte
 	}
+	// LineNo holds the line numbers of the captured movement and status
+	// lines in the input to Split. They are copied to the parsed steps.
+	// When the input is the normalized lines of a parser.Report_t, its
+	// Source method maps them back to the original report.
+	LineNo struct {
+		ScoutLines []int
+		Status     int
+		UnitMoves  int
+	}
 	Unit   *ast.Unit_t
 	Errors []error // error from parsing the unit header
+	Error  error   // error that stopped the parse, if any
 }
 
-var (
-	Units []*ast.Unit_t
-)
-
 // Less returns true if section should be sorted before another section.
 // We sort by clan, then unit, then line number.
 func (s *Section) Less(s2 *Section) bool {
-	if s.ClanId < s.ClanId {
+	if s.ClanId < s2.ClanId {
 		return true
-	} else if s.ClanId == s.ClanId {
-		sop := bytes.Compare(s.UnitId, s.UnitId)
+	} else if s.ClanId == s2.ClanId {
+		sop := bytes.Compare(s.UnitId, s2.UnitId)
 		if sop < 0 {
 			return true
 		} else if sop == 0 {
-			return s.Line < s.Line
+			return s.Line < s2.Line
 		}
 	}
 	return false
@@ -59,7 +66,13 @@ const (
 	debugScoutLines = false
 )
 
+// Parse parses the lines in the section and sets the unit.
+// It logs to the standard logger.
 func (s *Section) Parse(path string) error {
+	return s.parse(path, log.Default())
+}
+
+func (s *Section) parse(path string, lg *log.Logger) error {
 	// sort the lines before parsing.
 	s.Sort()
 
@@ -71,7 +84,7 @@ func (s *Section) Parse(path string) error {
 	}
 	if v, err := units.Parse(path, s.Lines.Unit); err != nil {
 		s.Errors = append(s.Errors, err)
-		log.Printf("section: header %q: parse error %v\n", s.Lines.Unit, err)
+		lg.Printf("section: header %q: parse error %v\n", s.Lines.Unit, err)
 		return err
 	} else if s.Unit, ok = v.(*ast.Unit_t); !ok {
 		panic(fmt.Sprintf("assert(%T == *UnitHeading_t)", v))
@@ -80,18 +93,20 @@ func (s *Section) Parse(path string) error {
 		//unitHeading.CurrentHex = uh.CurrentHex
 		//unitHeading.PreviousHex = uh.PreviousHex
 		//unitHeading.Error = uh.Error
-		//log.Printf("section: header %q: unit heading: %+v", s.Lines.Unit, *s.Unit)
+		//lg.Printf("section: header %q: unit heading: %+v", s.Lines.Unit, *s.Unit)
 	}
 
 	// turn line is optional, even though it's required in the spec
 	if s.Lines.Turn != nil {
 		if v, err := turns.Parse(path, s.Lines.Turn); err != nil {
 			s.Errors = append(s.Errors, err)
-			log.Printf("section: turn %q: parse error %v\n", s.Lines.Turn, err)
+			lg.Printf("section: turn %q: parse error %v\n", s.Lines.Turn, err)
 		} else if s.Unit.Turn, ok = v.(*ast.Turn_t); !ok {
 			panic(fmt.Sprintf("assert(%T == *Turn_t)", v))
 		} else {
-			//log.Printf("section: turn %q: %+v", s.Lines.Turn, s.Unit.Turn)
+			s.Unit.Turn.Season, s.Unit.Turn.Weather, _ = turns.SeasonAndWeather(s.Lines.Turn)
+			s.Unit.Turn.Next, s.Unit.Turn.ReportDate, _ = turns.NextTurn(s.Lines.Turn)
+			//lg.Printf("section: turn %q: %+v", s.Lines.Turn, s.Unit.Turn)
 		}
 	}
 
@@ -110,10 +125,13 @@ func (s *Section) Parse(path string) error {
 			s.Unit.Moves = &ast.Moves_t{GoesTo: c}
 		}
 	} else if s.Lines.UnitMoves != nil {
-		log.Printf("section: unit moves %q\n", s.Lines.UnitMoves)
+		lg.Printf("section: unit moves %q\n", s.Lines.UnitMoves)
 		if m, err := common.ParseTribeMovement(s.Unit.Turn, s.Unit.Id, s.Unit.PreviousHex, s.Lines.UnitMoves); err != nil {
 			s.Unit.Moves = &ast.Moves_t{Errors: []error{err}}
 		} else {
+			for _, step := range m {
+				step.Line = s.LineNo.UnitMoves
+			}
 			s.Unit.Moves = &ast.Moves_t{Marches: m}
 		}
 	} else if s.Lines.FleetMoves != nil {
@@ -123,11 +141,11 @@ func (s *Section) Parse(path string) error {
 	// scouting lines are optional and always start in the unit's current location.
 	for no, line := range s.Lines.ScoutLines {
 		if debugScoutLines {
-			log.Printf("section: scout line %d: %q\n", no+1, line)
+			lg.Printf("section: scout line %d: %q\n", no+1, line)
 		}
 		list, err := common.ParseScoutMovement(s.Unit.Turn, s.Unit.Id, s.Unit.CurrentHex, line)
 		if err != nil {
-			log.Printf("section: scout line %d: %v\n", no+1, err)
+			lg.Printf("section: scout line %d: %v\n", no+1, err)
 			if s.Unit.Moves == nil {
 				s.Unit.Moves = &ast.Moves_t{}
 			}
@@ -137,6 +155,9 @@ func (s *Section) Parse(path string) error {
 				s.Unit.Moves = &ast.Moves_t{}
 			}
 			for _, elem := range list {
+				if no < len(s.LineNo.ScoutLines) {
+					elem.Line = s.LineNo.ScoutLines[no]
+				}
 				s.Unit.Moves.Patrols = append(s.Unit.Moves.Patrols, elem)
 			}
 		}
@@ -148,36 +169,48 @@ func (s *Section) Parse(path string) error {
 		// should be an error but the setup reports often don't include it.
 	} else if us, err := common.ParseUnitStatus(s.Unit.Turn, s.Unit.CurrentHex, s.Lines.Status); err != nil {
 		s.Errors = append(s.Errors, err)
-		log.Printf("section: status %q: parse error %v\n", s.Lines.Status, err)
+		lg.Printf("section: status %q: parse error %v\n", s.Lines.Status, err)
 	} else {
+		us.Line = s.LineNo.Status
 		s.Unit.Status = us
 	}
 
+	// people and goods blocks are optional.
+	if s.Lines.Inventory != nil {
+		if inv, err := common.ParseInventory(s.Unit.Turn, s.Unit.Id, s.Lines.Inventory); err != nil {
+			s.Errors = append(s.Errors, err)
+			lg.Printf("section: inventory %q: parse error %v\n", s.Lines.Inventory, err)
+		} else {
+			s.Unit.Inventory = inv
+		}
+	}
+
 	if s.Unit != nil {
-		//log.Printf("section: %q\n", s.Lines.Unit)
-		//log.Printf("section: unit\n%+v\n", *s.Unit)
+		//lg.Printf("section: %q\n", s.Lines.Unit)
+		//lg.Printf("section: unit\n%+v\n", *s.Unit)
 		if s.Unit.Status != nil {
-			//log.Printf("section: status\n%+v\n", *s.Unit.Status)
+			//lg.Printf("section: status\n%+v\n", *s.Unit.Status)
 		}
 		if s.Unit.Moves != nil {
-			log.Printf("section: moves\n%+v\n", *s.Unit.Moves)
+			lg.Printf("section: moves\n%+v\n", *s.Unit.Moves)
 		}
 	}
 
-	log.Printf("section: unit    %q\n", s.Lines.Unit)
-	log.Printf("section: turn    %q\n", s.Lines.Turn)
+	lg.Printf("section: unit    %q\n", s.Lines.Unit)
+	lg.Printf("section: turn    %q\n", s.Lines.Turn)
 	if len(s.Lines.UnitMoves) != 0 {
-		log.Printf("section: marches %q\n", s.Lines.UnitMoves)
+		lg.Printf("section: marches %q\n", s.Lines.UnitMoves)
 	}
 	for no, line := range s.Lines.ScoutLines {
 		if len(line) != 0 {
-			log.Printf("section: patrols %d %q\n", no+1, line)
+			lg.Printf("section: patrols %d %q\n", no+1, line)
 		}
 	}
-	log.Printf("section: status  %q\n", s.Lines.Status)
-	log.Printf("unit: %s", s.Dump())
+	lg.Printf("section: status  %q\n", s.Lines.Status)
 
-	Units = append(Units, s.Unit)
+	s.validate(lg)
+
+	lg.Printf("unit: %s", s.Dump())
 
 	return nil
 }
@@ -225,6 +258,10 @@ func DumpSections(sections []*Section, separateUnits bool) []byte {
 			b.Write(s.Lines.Status)
 			b.WriteByte('\n')
 		}
+		for _, line := range s.Lines.Inventory {
+			b.Write(line)
+			b.WriteByte('\n')
+		}
 	}
 	return b.Bytes()
 }
@@ -6,43 +6,39 @@ import (
 	"bytes"
 	"github.com/playbymail/tribal/is"
 	"github.com/playbymail/tribal/norm"
-	"sort"
 	"strconv"
 )
 
-// DebugConfig struct to hold the flag values
-var DebugConfig struct {
-	SplitTurns   bool
-	SplitFollows bool
-	SplitGoesTo  bool
-	SplitMarches bool
-	SplitSails   bool
-	SplitPatrols bool
-	SplitStatus  bool
-}
-
 // Split splits the input report into sections.
 // Each section contains the header and move data for a single unit.
+// The parser's config selects the kinds of lines that are captured.
 // All other lines are ignored.
 //
 // We assume the caller has not done any clean up on the input.
 //
-// Returns a list of sections sorted by clan id, then by unit id, then by line number.
+// Returns a list of sections in report order.
 //
 // Warnings:
 //   - All input is converted to lowercase to make comparisons easier in future stages.
 //   - Report sections sometimes are missing the Status line. We can't depend on it to close out a section.
 //   - Sections can contain multiple turn lines because of the missing Status line. When that happens,
 //     we capture the additional turn lines and hope that someone eventually reports an error.
-func Split(input []byte) (sections []*Section) {
+func (p *Parser) Split(input []byte) (sections []*Section) {
 	input = norm.NormalizeSpaces(input)
 	input = norm.NormalizeCase(input)
 	input = norm.LineEndings(input)
 
-	var section *Section
+	// inventory is the section that owns the current block of people or goods.
+	// the blocks follow the status line, so they are captured after section is closed.
+	var section, inventory *Section
 	for no, line := range bytes.Split(input, []byte{'\n'}) {
 		//log.Printf("section: %d: %q\n", no, line)
+		if inventory != nil && !is.InventoryHeading(line) && !is.InventoryLine(line) && len(line) != 0 {
+			// the block ends at the first line that can't be part of it
+			inventory = nil
+		}
 		if is.UnitHeader(line) {
+			inventory = nil
 			// add a new section every time we change units
 			section = &Section{
 				Id:   len(sections) + 1,
@@ -55,47 +51,62 @@ func Split(input []byte) (sections []*Section) {
 			unitUnitId, _, _ := bytes.Cut(line, []byte{','})
 			_, section.UnitId, _ = bytes.Cut(unitUnitId, []byte{' '})
 			section.ClanId, _ = strconv.Atoi(string(section.UnitId[1:4]))
+		} else if is.InventoryHeading(line) {
+			// the block belongs to the most recent unit header
+			if inventory = nil; len(sections) != 0 {
+				inventory = sections[len(sections)-1]
+			}
+			if p.Config.SplitInventory && inventory != nil {
+				inventory.Lines.Inventory = append(inventory.Lines.Inventory, bdup(line))
+			}
+		} else if inventory != nil && len(line) != 0 {
+			if p.Config.SplitInventory {
+				inventory.Lines.Inventory = append(inventory.Lines.Inventory, bdup(line))
+			}
 		} else if section == nil {
 			//log.Printf("section: %d: ignoring line %q\n", no, line)
 			continue
 		} else if is.FleetMovement(line) {
-			if DebugConfig.SplitSails {
+			if p.Config.SplitSails {
 				if section.Lines.FleetMoves == nil {
 					section.Lines.FleetMoves = norm.FleetMovement(line)
 				}
 			}
 		} else if is.TribeFollows(line) {
-			if DebugConfig.SplitFollows {
+			if p.Config.SplitFollows {
 				if section.Lines.UnitFollows == nil {
 					section.Lines.UnitFollows = bdup(line)
 				}
 			}
 		} else if is.TribeGoesTo(line) {
-			if DebugConfig.SplitGoesTo {
+			if p.Config.SplitGoesTo {
 				if section.Lines.UnitGoesTo == nil {
 					section.Lines.UnitGoesTo = bdup(line)
 				}
 			}
 		} else if is.TribeMovement(line) {
-			if DebugConfig.SplitMarches {
+			if p.Config.SplitMarches {
 				if section.Lines.UnitMoves == nil {
 					section.Lines.UnitMoves = norm.TribeMovement(line)
+					section.LineNo.UnitMoves = no + 1
 				}
 			}
 		} else if is.ScoutLine(line) {
-			if DebugConfig.SplitPatrols {
+			if p.Config.SplitPatrols {
 				section.Lines.ScoutLines = append(section.Lines.ScoutLines, norm.ScoutMovement(line))
+				section.LineNo.ScoutLines = append(section.LineNo.ScoutLines, no+1)
 			}
 		} else if is.TurnHeader(line) {
-			if DebugConfig.SplitTurns {
+			if p.Config.SplitTurns {
 				if section.Lines.Turn == nil {
 					section.Lines.Turn = bdup(line)
 				}
 			}
 		} else if is.UnitStatus(line) {
-			if DebugConfig.SplitStatus {
+			if p.Config.SplitStatus {
 				if section.Lines.Status == nil {
 					section.Lines.Status = norm.UnitStatus(line)
+					section.LineNo.Status = no + 1
 				}
 			}
 			// set `section` to nil to avoid capturing lines between sections.
@@ -103,11 +114,6 @@ func Split(input []byte) (sections []*Section) {
 		}
 	}
 
-	// sort the sections by unit then line number.
-	sort.Slice(sections, func(i, j int) bool {
-		return sections[i].Less(sections[j])
-	})
-
 	return sections
 }
 
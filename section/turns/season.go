@@ -0,0 +1,43 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package turns
+
+import (
+	"github.com/playbymail/tribal/parser/ast"
+	"regexp"
+	"strconv"
+)
+
+var (
+	reNextTurn      = regexp.MustCompile(`\bnext turn (\d{3,4})-(\d{1,2})\(#(\d+)\),(\S+)`)
+	reSeasonWeather = regexp.MustCompile(`^current turn \d{3,4}-\d{1,2}\(#\d+\),([a-z]+),([a-z]+)`)
+)
+
+// SeasonAndWeather returns the season and weather from the turn line.
+// The grammar doesn't capture them because they aren't needed for mapping,
+// but we need them to write the turn line back out.
+//
+// Assumes that the line has already been cleaned up and converted to lower case.
+func SeasonAndWeather(line []byte) (season, weather string, ok bool) {
+	match := reSeasonWeather.FindSubmatch(line)
+	if match == nil {
+		return "", "", false
+	}
+	return string(match[1]), string(match[2]), true
+}
+
+// NextTurn returns the next turn and the report date from the turn line.
+// Only the clan's section has them. The grammar doesn't capture them for
+// the same reason that it doesn't capture the season and weather.
+//
+// Assumes that the line has already been cleaned up and converted to lower case.
+func NextTurn(line []byte) (next *ast.Turn_t, reportDate string, ok bool) {
+	match := reNextTurn.FindSubmatch(line)
+	if match == nil {
+		return nil, "", false
+	}
+	year, _ := strconv.Atoi(string(match[1]))
+	month, _ := strconv.Atoi(string(match[2]))
+	no, _ := strconv.Atoi(string(match[3]))
+	return &ast.Turn_t{Id: ast.TurnId_t(no), Year: year, Month: month}, string(match[4]), true
+}
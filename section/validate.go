@@ -0,0 +1,63 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package section
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/terrain"
+	"log"
+)
+
+// validate checks that the movement and status of the unit agree with each
+// other and with the unit header. Each violation is added to the unit's errors.
+// The lines parse on their own, so these are usually transcription errors by
+// the GM rather than parser errors.
+func (s *Section) validate(lg *log.Logger) {
+	u := s.Unit
+	if u == nil {
+		return
+	}
+	violation := func(err error) {
+		lg.Printf("section: %d: unit %s: %v\n", s.Line, u.Id, err)
+		u.Errors = append(u.Errors, err)
+	}
+
+	var last *ast.March_t
+	if u.Moves != nil && len(u.Moves.Marches) != 0 {
+		last = u.Moves.Marches[len(u.Moves.Marches)-1]
+	}
+	if last != nil && !sameHex(last.To, u.CurrentHex) {
+		violation(fmt.Errorf("line %d: last move ends in %s, header says %s: %w", last.Line, last.To, u.CurrentHex, ast.ErrCurrentHexMismatch))
+	}
+
+	st := u.Status
+	if st == nil {
+		return
+	}
+	if last != nil && last.Terrain != terrain.Blank && st.Tile.Terrain != terrain.Blank && last.Terrain != st.Tile.Terrain {
+		violation(fmt.Errorf("line %d: last move ends in %s, status says %s: %w", st.Line, last.Terrain, st.Tile.Terrain, ast.ErrStatusTerrainMismatch))
+	}
+	if st.Unit != u.Id {
+		violation(fmt.Errorf("line %d: status is for %q, header is for %q: %w", st.Line, st.Unit, u.Id, ast.ErrStatusUnitMismatch))
+	}
+	found := false
+	for _, id := range st.Tile.Encounters {
+		found = found || id == u.Id
+	}
+	if !found {
+		violation(fmt.Errorf("line %d: %s: %w", st.Line, u.Id, ast.ErrUnitNotEncountered))
+	}
+}
+
+// sameHex returns true if the coordinates are for the same hex.
+// Early reports obscure the grid, so we only compare the grid if
+// both coordinates have one.
+func sameHex(a, b ast.Coordinates_t) bool {
+	if a.IsZero() || b.IsZero() {
+		return true
+	} else if a.IsValidGrid() && b.IsValidGrid() {
+		return a == b
+	}
+	return a.Column == b.Column && a.Row == b.Row
+}
@@ -72,7 +72,24 @@ type PassageCode struct {
 }
 
 type ReportFile struct {
+	ID          int64
+	Hash        string
+	Fingerprint string
+	Name        string
+	Original    []byte
+	CreatedAt   int64
+}
+
+type ReportLine struct {
+	ReportID int64
+	LineNo   int64
+	SourceNo int64
+	Line     string
+}
+
+type ReportLink struct {
 	ID        int64
+	ReportID  int64
 	Hash      string
 	Name      string
 	CreatedAt int64
@@ -166,3 +183,21 @@ type Unit struct {
 	ClanNo  int64
 	IsScout int64
 }
+
+type UnitInventory struct {
+	ClanNo   int64
+	TurnNo   int64
+	UnitID   string
+	ItemCd   string
+	Quantity int64
+}
+
+type UnitPopulation struct {
+	ClanNo    int64
+	TurnNo    int64
+	UnitID    string
+	People    int64
+	Warriors  int64
+	Actives   int64
+	Inactives int64
+}
@@ -7,6 +7,44 @@
 INSERT INTO clans (id, name)
 VALUES (:id, :name);
 
+-- --------------------------------------------------------------------------
+-- CreateMove adds a step of the unit's movement for the turn.
+-- The failure reason is null unless the step failed.
+--
+-- name: CreateMove :exec
+INSERT INTO moves (clan_no, turn_no, unit_id, step_no, starting_tile, action, ending_tile, terrain_cd, failure_reason)
+VALUES (:clan_no, :turn_no, :unit_id, :step_no, :starting_tile, :action, :ending_tile, :terrain_cd, :failure_reason);
+
+-- --------------------------------------------------------------------------
+-- CreateReportFile creates a new report file and returns its id.
+--
+-- name: CreateReportFile :one
+INSERT INTO report_files (hash, fingerprint, name, original)
+VALUES (:hash, :fingerprint, :name, :original)
+RETURNING id;
+
+-- --------------------------------------------------------------------------
+-- CreateReportLine adds a normalized line to a report file.
+--
+-- name: CreateReportLine :exec
+INSERT INTO report_lines (report_id, line_no, source_no, line)
+VALUES (:report_id, :line_no, :source_no, :line);
+
+-- --------------------------------------------------------------------------
+-- CreateReportLink links a file to an existing report file.
+--
+-- name: CreateReportLink :exec
+INSERT INTO report_links (report_id, hash, name)
+VALUES (:report_id, :hash, :name);
+
+-- --------------------------------------------------------------------------
+-- CreateTile creates a new tile and returns its id.
+--
+-- name: CreateTile :one
+INSERT INTO tiles (grid, row, col)
+VALUES (:grid, :row, :col)
+RETURNING id;
+
 -- --------------------------------------------------------------------------
 -- CreateTurn creates a new turn.
 -- If the turn already exists, it ignores the request.
@@ -15,6 +53,29 @@ VALUES (:id, :name);
 INSERT INTO turns (id, year, month)
 VALUES (:id, :year, :month);
 
+-- --------------------------------------------------------------------------
+-- CreateUnit creates a new unit.
+-- If the unit already exists, it ignores the request.
+--
+-- name: CreateUnit :exec
+INSERT INTO units (id, clan_no, is_scout)
+VALUES (:id, :clan_no, :is_scout)
+ON CONFLICT (id) DO NOTHING;
+
+-- --------------------------------------------------------------------------
+-- CreateUnitInventory adds an item to the unit's inventory for the turn.
+--
+-- name: CreateUnitInventory :exec
+INSERT INTO unit_inventories (clan_no, turn_no, unit_id, item_cd, quantity)
+VALUES (:clan_no, :turn_no, :unit_id, :item_cd, :quantity);
+
+-- --------------------------------------------------------------------------
+-- CreateUnitPopulation adds the unit's population for the turn.
+--
+-- name: CreateUnitPopulation :exec
+INSERT INTO unit_populations (clan_no, turn_no, unit_id, people, warriors, actives, inactives)
+VALUES (:clan_no, :turn_no, :unit_id, :people, :warriors, :actives, :inactives);
+
 -- --------------------------------------------------------------------------
 -- GetReportByHash returns the report file with the given hash.
 --
@@ -23,6 +84,52 @@ SELECT id, name, created_at
 FROM report_files
 WHERE hash = :hash;
 
+-- --------------------------------------------------------------------------
+-- GetReportByFingerprint returns the first report file with the given fingerprint.
+--
+-- name: GetReportByFingerprint :one
+SELECT id, hash, name, created_at
+FROM report_files
+WHERE fingerprint = :fingerprint
+ORDER BY id
+LIMIT 1;
+
+-- --------------------------------------------------------------------------
+-- GetReportLines returns the normalized lines of the report file.
+--
+-- name: GetReportLines :many
+SELECT line_no, source_no, line
+FROM report_lines
+WHERE report_id = :report_id
+ORDER BY line_no;
+
+-- --------------------------------------------------------------------------
+-- GetReportLinkByHash returns the report file that a file with the given hash is linked to.
+--
+-- name: GetReportLinkByHash :one
+SELECT report_files.id, report_files.name, report_links.created_at
+FROM report_links
+         JOIN report_files ON report_files.id = report_links.report_id
+WHERE report_links.hash = :hash;
+
+-- --------------------------------------------------------------------------
+-- GetReportOriginal returns the compressed contents of the report file.
+--
+-- name: GetReportOriginal :one
+SELECT original
+FROM report_files
+WHERE id = :id;
+
+-- --------------------------------------------------------------------------
+-- GetTileId returns the id of the tile at the given location.
+--
+-- name: GetTileId :one
+SELECT id
+FROM tiles
+WHERE grid = :grid
+  AND row = :row
+  AND col = :col;
+
 -- --------------------------------------------------------------------------
 -- GetTurnNo returns the turn number for the given year and month.
 --
@@ -30,4 +137,46 @@ WHERE hash = :hash;
 SELECT id
 FROM turns
 WHERE year = :year
-  AND month = :month;
\ No newline at end of file
+  AND month = :month;
+
+-- --------------------------------------------------------------------------
+-- GetUnitInventories returns the unit's inventory for every turn.
+--
+-- name: GetUnitInventories :many
+SELECT turn_no, item_cd, quantity
+FROM unit_inventories
+WHERE clan_no = :clan_no
+  AND unit_id = :unit_id
+ORDER BY turn_no, item_cd;
+
+-- --------------------------------------------------------------------------
+-- GetUnitMoves returns the unit's movement for the turn.
+--
+-- name: GetUnitMoves :many
+SELECT moves.step_no,
+       starting.grid AS from_grid,
+       starting.row  AS from_row,
+       starting.col  AS from_col,
+       moves.action,
+       ending.grid   AS to_grid,
+       ending.row    AS to_row,
+       ending.col    AS to_col,
+       moves.terrain_cd,
+       moves.failure_reason
+FROM moves
+         JOIN tiles starting ON starting.id = moves.starting_tile
+         JOIN tiles ending ON ending.id = moves.ending_tile
+WHERE moves.clan_no = :clan_no
+  AND moves.turn_no = :turn_no
+  AND moves.unit_id = :unit_id
+ORDER BY moves.step_no;
+
+-- --------------------------------------------------------------------------
+-- GetUnitPopulations returns the unit's population for every turn.
+--
+-- name: GetUnitPopulations :many
+SELECT turn_no, people, warriors, actives, inactives
+FROM unit_populations
+WHERE clan_no = :clan_no
+  AND unit_id = :unit_id
+ORDER BY turn_no;
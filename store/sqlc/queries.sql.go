@@ -7,6 +7,7 @@ package sqlc
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createClan = `-- name: CreateClan :exec
@@ -29,6 +30,131 @@ func (q *Queries) CreateClan(ctx context.Context, arg CreateClanParams) error {
 	return err
 }
 
+const createMove = `-- name: CreateMove :exec
+INSERT INTO moves (clan_no, turn_no, unit_id, step_no, starting_tile, action, ending_tile, terrain_cd, failure_reason)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7, ?8, ?9)
+`
+
+type CreateMoveParams struct {
+	ClanNo        int64
+	TurnNo        int64
+	UnitID        string
+	StepNo        int64
+	StartingTile  int64
+	Action        string
+	EndingTile    int64
+	TerrainCd     string
+	FailureReason sql.NullString
+}
+
+// --------------------------------------------------------------------------
+// CreateMove adds a step of the unit's movement for the turn.
+// The failure reason is null unless the step failed.
+func (q *Queries) CreateMove(ctx context.Context, arg CreateMoveParams) error {
+	_, err := q.db.ExecContext(ctx, createMove,
+		arg.ClanNo,
+		arg.TurnNo,
+		arg.UnitID,
+		arg.StepNo,
+		arg.StartingTile,
+		arg.Action,
+		arg.EndingTile,
+		arg.TerrainCd,
+		arg.FailureReason,
+	)
+	return err
+}
+
+const createReportFile = `-- name: CreateReportFile :one
+INSERT INTO report_files (hash, fingerprint, name, original)
+VALUES (?1, ?2, ?3, ?4)
+RETURNING id
+`
+
+type CreateReportFileParams struct {
+	Hash        string
+	Fingerprint string
+	Name        string
+	Original    []byte
+}
+
+// --------------------------------------------------------------------------
+// CreateReportFile creates a new report file and returns its id.
+func (q *Queries) CreateReportFile(ctx context.Context, arg CreateReportFileParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createReportFile,
+		arg.Hash,
+		arg.Fingerprint,
+		arg.Name,
+		arg.Original,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
+const createReportLine = `-- name: CreateReportLine :exec
+INSERT INTO report_lines (report_id, line_no, source_no, line)
+VALUES (?1, ?2, ?3, ?4)
+`
+
+type CreateReportLineParams struct {
+	ReportID int64
+	LineNo   int64
+	SourceNo int64
+	Line     string
+}
+
+// --------------------------------------------------------------------------
+// CreateReportLine adds a normalized line to a report file.
+func (q *Queries) CreateReportLine(ctx context.Context, arg CreateReportLineParams) error {
+	_, err := q.db.ExecContext(ctx, createReportLine,
+		arg.ReportID,
+		arg.LineNo,
+		arg.SourceNo,
+		arg.Line,
+	)
+	return err
+}
+
+const createReportLink = `-- name: CreateReportLink :exec
+INSERT INTO report_links (report_id, hash, name)
+VALUES (?1, ?2, ?3)
+`
+
+type CreateReportLinkParams struct {
+	ReportID int64
+	Hash     string
+	Name     string
+}
+
+// --------------------------------------------------------------------------
+// CreateReportLink links a file to an existing report file.
+func (q *Queries) CreateReportLink(ctx context.Context, arg CreateReportLinkParams) error {
+	_, err := q.db.ExecContext(ctx, createReportLink, arg.ReportID, arg.Hash, arg.Name)
+	return err
+}
+
+const createTile = `-- name: CreateTile :one
+INSERT INTO tiles (grid, row, col)
+VALUES (?1, ?2, ?3)
+RETURNING id
+`
+
+type CreateTileParams struct {
+	Grid string
+	Row  int64
+	Col  int64
+}
+
+// --------------------------------------------------------------------------
+// CreateTile creates a new tile and returns its id.
+func (q *Queries) CreateTile(ctx context.Context, arg CreateTileParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, createTile, arg.Grid, arg.Row, arg.Col)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const createTurn = `-- name: CreateTurn :exec
 INSERT INTO turns (id, year, month)
 VALUES (?1, ?2, ?3)
@@ -48,6 +174,82 @@ func (q *Queries) CreateTurn(ctx context.Context, arg CreateTurnParams) error {
 	return err
 }
 
+const createUnit = `-- name: CreateUnit :exec
+INSERT INTO units (id, clan_no, is_scout)
+VALUES (?1, ?2, ?3)
+ON CONFLICT (id) DO NOTHING
+`
+
+type CreateUnitParams struct {
+	ID      string
+	ClanNo  int64
+	IsScout int64
+}
+
+// --------------------------------------------------------------------------
+// CreateUnit creates a new unit.
+// If the unit already exists, it ignores the request.
+func (q *Queries) CreateUnit(ctx context.Context, arg CreateUnitParams) error {
+	_, err := q.db.ExecContext(ctx, createUnit, arg.ID, arg.ClanNo, arg.IsScout)
+	return err
+}
+
+const createUnitInventory = `-- name: CreateUnitInventory :exec
+INSERT INTO unit_inventories (clan_no, turn_no, unit_id, item_cd, quantity)
+VALUES (?1, ?2, ?3, ?4, ?5)
+`
+
+type CreateUnitInventoryParams struct {
+	ClanNo   int64
+	TurnNo   int64
+	UnitID   string
+	ItemCd   string
+	Quantity int64
+}
+
+// --------------------------------------------------------------------------
+// CreateUnitInventory adds an item to the unit's inventory for the turn.
+func (q *Queries) CreateUnitInventory(ctx context.Context, arg CreateUnitInventoryParams) error {
+	_, err := q.db.ExecContext(ctx, createUnitInventory,
+		arg.ClanNo,
+		arg.TurnNo,
+		arg.UnitID,
+		arg.ItemCd,
+		arg.Quantity,
+	)
+	return err
+}
+
+const createUnitPopulation = `-- name: CreateUnitPopulation :exec
+INSERT INTO unit_populations (clan_no, turn_no, unit_id, people, warriors, actives, inactives)
+VALUES (?1, ?2, ?3, ?4, ?5, ?6, ?7)
+`
+
+type CreateUnitPopulationParams struct {
+	ClanNo    int64
+	TurnNo    int64
+	UnitID    string
+	People    int64
+	Warriors  int64
+	Actives   int64
+	Inactives int64
+}
+
+// --------------------------------------------------------------------------
+// CreateUnitPopulation adds the unit's population for the turn.
+func (q *Queries) CreateUnitPopulation(ctx context.Context, arg CreateUnitPopulationParams) error {
+	_, err := q.db.ExecContext(ctx, createUnitPopulation,
+		arg.ClanNo,
+		arg.TurnNo,
+		arg.UnitID,
+		arg.People,
+		arg.Warriors,
+		arg.Actives,
+		arg.Inactives,
+	)
+	return err
+}
+
 const getReportByHash = `-- name: GetReportByHash :one
 SELECT id, name, created_at
 FROM report_files
@@ -69,6 +271,133 @@ func (q *Queries) GetReportByHash(ctx context.Context, hash string) (GetReportBy
 	return i, err
 }
 
+const getReportByFingerprint = `-- name: GetReportByFingerprint :one
+SELECT id, hash, name, created_at
+FROM report_files
+WHERE fingerprint = ?1
+ORDER BY id
+LIMIT 1
+`
+
+type GetReportByFingerprintRow struct {
+	ID        int64
+	Hash      string
+	Name      string
+	CreatedAt int64
+}
+
+// --------------------------------------------------------------------------
+// GetReportByFingerprint returns the first report file with the given fingerprint.
+func (q *Queries) GetReportByFingerprint(ctx context.Context, fingerprint string) (GetReportByFingerprintRow, error) {
+	row := q.db.QueryRowContext(ctx, getReportByFingerprint, fingerprint)
+	var i GetReportByFingerprintRow
+	err := row.Scan(
+		&i.ID,
+		&i.Hash,
+		&i.Name,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getReportLines = `-- name: GetReportLines :many
+SELECT line_no, source_no, line
+FROM report_lines
+WHERE report_id = ?1
+ORDER BY line_no
+`
+
+type GetReportLinesRow struct {
+	LineNo   int64
+	SourceNo int64
+	Line     string
+}
+
+// --------------------------------------------------------------------------
+// GetReportLines returns the normalized lines of the report file.
+func (q *Queries) GetReportLines(ctx context.Context, reportID int64) ([]GetReportLinesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReportLines, reportID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReportLinesRow
+	for rows.Next() {
+		var i GetReportLinesRow
+		if err := rows.Scan(&i.LineNo, &i.SourceNo, &i.Line); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReportLinkByHash = `-- name: GetReportLinkByHash :one
+SELECT report_files.id, report_files.name, report_links.created_at
+FROM report_links
+         JOIN report_files ON report_files.id = report_links.report_id
+WHERE report_links.hash = ?1
+`
+
+type GetReportLinkByHashRow struct {
+	ID        int64
+	Name      string
+	CreatedAt int64
+}
+
+// --------------------------------------------------------------------------
+// GetReportLinkByHash returns the report file that a file with the given hash is linked to.
+func (q *Queries) GetReportLinkByHash(ctx context.Context, hash string) (GetReportLinkByHashRow, error) {
+	row := q.db.QueryRowContext(ctx, getReportLinkByHash, hash)
+	var i GetReportLinkByHashRow
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const getReportOriginal = `-- name: GetReportOriginal :one
+SELECT original
+FROM report_files
+WHERE id = ?1
+`
+
+// --------------------------------------------------------------------------
+// GetReportOriginal returns the compressed contents of the report file.
+func (q *Queries) GetReportOriginal(ctx context.Context, id int64) ([]byte, error) {
+	row := q.db.QueryRowContext(ctx, getReportOriginal, id)
+	var original []byte
+	err := row.Scan(&original)
+	return original, err
+}
+
+const getTileId = `-- name: GetTileId :one
+SELECT id
+FROM tiles
+WHERE grid = ?1
+  AND row = ?2
+  AND col = ?3
+`
+
+type GetTileIdParams struct {
+	Grid string
+	Row  int64
+	Col  int64
+}
+
+// --------------------------------------------------------------------------
+// GetTileId returns the id of the tile at the given location.
+func (q *Queries) GetTileId(ctx context.Context, arg GetTileIdParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getTileId, arg.Grid, arg.Row, arg.Col)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getTurnNo = `-- name: GetTurnNo :one
 SELECT id
 FROM turns
@@ -89,3 +418,174 @@ func (q *Queries) GetTurnNo(ctx context.Context, arg GetTurnNoParams) (int64, er
 	err := row.Scan(&id)
 	return id, err
 }
+
+const getUnitInventories = `-- name: GetUnitInventories :many
+SELECT turn_no, item_cd, quantity
+FROM unit_inventories
+WHERE clan_no = ?1
+  AND unit_id = ?2
+ORDER BY turn_no, item_cd
+`
+
+type GetUnitInventoriesParams struct {
+	ClanNo int64
+	UnitID string
+}
+
+type GetUnitInventoriesRow struct {
+	TurnNo   int64
+	ItemCd   string
+	Quantity int64
+}
+
+// --------------------------------------------------------------------------
+// GetUnitInventories returns the unit's inventory for every turn.
+func (q *Queries) GetUnitInventories(ctx context.Context, arg GetUnitInventoriesParams) ([]GetUnitInventoriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUnitInventories, arg.ClanNo, arg.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUnitInventoriesRow
+	for rows.Next() {
+		var i GetUnitInventoriesRow
+		if err := rows.Scan(&i.TurnNo, &i.ItemCd, &i.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnitMoves = `-- name: GetUnitMoves :many
+SELECT moves.step_no,
+       starting.grid AS from_grid,
+       starting.row  AS from_row,
+       starting.col  AS from_col,
+       moves.action,
+       ending.grid   AS to_grid,
+       ending.row    AS to_row,
+       ending.col    AS to_col,
+       moves.terrain_cd,
+       moves.failure_reason
+FROM moves
+         JOIN tiles starting ON starting.id = moves.starting_tile
+         JOIN tiles ending ON ending.id = moves.ending_tile
+WHERE moves.clan_no = ?1
+  AND moves.turn_no = ?2
+  AND moves.unit_id = ?3
+ORDER BY moves.step_no
+`
+
+type GetUnitMovesParams struct {
+	ClanNo int64
+	TurnNo int64
+	UnitID string
+}
+
+type GetUnitMovesRow struct {
+	StepNo        int64
+	FromGrid      string
+	FromRow       int64
+	FromCol       int64
+	Action        string
+	ToGrid        string
+	ToRow         int64
+	ToCol         int64
+	TerrainCd     string
+	FailureReason sql.NullString
+}
+
+// --------------------------------------------------------------------------
+// GetUnitMoves returns the unit's movement for the turn.
+func (q *Queries) GetUnitMoves(ctx context.Context, arg GetUnitMovesParams) ([]GetUnitMovesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUnitMoves, arg.ClanNo, arg.TurnNo, arg.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUnitMovesRow
+	for rows.Next() {
+		var i GetUnitMovesRow
+		if err := rows.Scan(
+			&i.StepNo,
+			&i.FromGrid,
+			&i.FromRow,
+			&i.FromCol,
+			&i.Action,
+			&i.ToGrid,
+			&i.ToRow,
+			&i.ToCol,
+			&i.TerrainCd,
+			&i.FailureReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnitPopulations = `-- name: GetUnitPopulations :many
+SELECT turn_no, people, warriors, actives, inactives
+FROM unit_populations
+WHERE clan_no = ?1
+  AND unit_id = ?2
+ORDER BY turn_no
+`
+
+type GetUnitPopulationsParams struct {
+	ClanNo int64
+	UnitID string
+}
+
+type GetUnitPopulationsRow struct {
+	TurnNo    int64
+	People    int64
+	Warriors  int64
+	Actives   int64
+	Inactives int64
+}
+
+// --------------------------------------------------------------------------
+// GetUnitPopulations returns the unit's population for every turn.
+func (q *Queries) GetUnitPopulations(ctx context.Context, arg GetUnitPopulationsParams) ([]GetUnitPopulationsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getUnitPopulations, arg.ClanNo, arg.UnitID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUnitPopulationsRow
+	for rows.Next() {
+		var i GetUnitPopulationsRow
+		if err := rows.Scan(
+			&i.TurnNo,
+			&i.People,
+			&i.Warriors,
+			&i.Actives,
+			&i.Inactives,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -12,6 +12,8 @@ DROP TABLE IF EXISTS move_transient_details;
 DROP TABLE IF EXISTS moves;
 DROP TABLE IF EXISTS passage_codes;
 DROP TABLE IF EXISTS report_files;
+DROP TABLE IF EXISTS report_lines;
+DROP TABLE IF EXISTS report_links;
 DROP TABLE IF EXISTS resource_codes;
 DROP TABLE IF EXISTS terrain_codes;
 DROP TABLE IF EXISTS tile_border_details;
@@ -22,6 +24,8 @@ DROP TABLE IF EXISTS tile_terrain_details;
 DROP TABLE IF EXISTS tile_transient_details;
 DROP TABLE IF EXISTS tiles;
 DROP TABLE IF EXISTS turns;
+DROP TABLE IF EXISTS unit_inventories;
+DROP TABLE IF EXISTS unit_populations;
 DROP TABLE IF EXISTS units;
 PRAGMA foreign_keys = ON;
 
@@ -35,14 +39,61 @@ PRAGMA foreign_keys = ON;
 --
 -- The hash is the SHA-1 hash of the report.
 --
+-- The fingerprint is the SHA-1 hash of the report's sections after
+-- normalization. Re-saving a report in Word, or converting it to text,
+-- changes the hash but not the fingerprint.
+--
 -- We don't care about the name of the file, so there are no constraints
 -- on it. We store it so that players can see what they've loaded based
 -- on the file name on their computer.
+--
+-- The original contents of the file are compressed with gzip and stored
+-- so that we can parse the report again after the parser is upgraded
+-- without asking the player for the file.
 CREATE TABLE report_files
 (
     id INTEGER NOT NULL PRIMARY KEY,
     hash TEXT NOT NULL UNIQUE,
+    fingerprint TEXT NOT NULL DEFAULT '',
     name TEXT NOT NULL,
+    original BLOB NOT NULL, -- gzip compressed contents of the file
+    created_at INTEGER NOT NULL DEFAULT (CAST(strftime('%s', 'now') AS INTEGER))
+);
+
+CREATE INDEX report_files_fingerprint ON report_files (fingerprint);
+
+-- --------------------------------------------------------------------------
+-- Report Lines
+--
+-- This table contains the lines of the report after normalization.
+-- Empty lines are not stored, so the line numbers don't match the
+-- original file. The source number is the line (for text files) or the
+-- paragraph (for Word documents) in the original file that the line
+-- came from. Diagnostics use it to point players at the right place.
+CREATE TABLE report_lines
+(
+    report_id INTEGER NOT NULL REFERENCES report_files (id),
+    line_no   INTEGER NOT NULL, -- 1-based line number after normalization
+    source_no INTEGER NOT NULL, -- 1-based line or paragraph number in the original file
+    line      TEXT    NOT NULL,
+    PRIMARY KEY (report_id, line_no)
+);
+
+-- --------------------------------------------------------------------------
+-- Report Links
+--
+-- This table contains files that have the same contents as a report that
+-- we've already loaded, usually because the player re-saved the report in
+-- Word or converted it to text. The file is linked to the existing report
+-- instead of being loaded again, which would double every move. The hash
+-- is the SHA-1 hash of the file, so that loading it again is caught as a
+-- duplicate.
+CREATE TABLE report_links
+(
+    id         INTEGER NOT NULL PRIMARY KEY,
+    report_id  INTEGER NOT NULL REFERENCES report_files (id),
+    hash       TEXT    NOT NULL UNIQUE,
+    name       TEXT    NOT NULL,
     created_at INTEGER NOT NULL DEFAULT (CAST(strftime('%s', 'now') AS INTEGER))
 );
 
@@ -167,6 +218,39 @@ CREATE TABLE units
     is_scout INTEGER NOT NULL DEFAULT 0 CHECK (is_scout in (0, 1)) -- true only if unit is a scout
 );
 
+-- --------------------------------------------------------------------------
+-- Unit Populations
+--
+-- This table stores the people in a unit at the end of a turn.
+-- It's loaded from the Humans block of the turn report.
+CREATE TABLE unit_populations
+(
+    clan_no   INTEGER NOT NULL REFERENCES clans (id),
+    turn_no   INTEGER NOT NULL REFERENCES turns (id),
+    unit_id   TEXT    NOT NULL REFERENCES units (id),
+    people    INTEGER NOT NULL,
+    warriors  INTEGER NOT NULL,
+    actives   INTEGER NOT NULL,
+    inactives INTEGER NOT NULL,
+    PRIMARY KEY (clan_no, turn_no, unit_id)
+);
+
+-- --------------------------------------------------------------------------
+-- Unit Inventories
+--
+-- This table stores the goods held by a unit at the end of a turn.
+-- It's loaded from the Possessions blocks of the turn report.
+-- Items that are listed more than once in a report are combined.
+CREATE TABLE unit_inventories
+(
+    clan_no  INTEGER NOT NULL REFERENCES clans (id),
+    turn_no  INTEGER NOT NULL REFERENCES turns (id),
+    unit_id  TEXT    NOT NULL REFERENCES units (id),
+    item_cd  TEXT    NOT NULL REFERENCES item_codes (code),
+    quantity INTEGER NOT NULL,
+    PRIMARY KEY (clan_no, turn_no, unit_id, item_cd)
+);
+
 -- --------------------------------------------------------------------------
 -- Border Codes
 --
@@ -855,7 +939,7 @@ CREATE TABLE moves
     action         TEXT    NOT NULL,    -- kind of movement (Still, Follow, Scout) or direction
     ending_tile    INTEGER NOT NULL REFERENCES tiles (id),
     terrain_cd     TEXT    NOT NULL REFERENCES terrain_codes (code),
-    failure_reason TEXT,                -- set only if the move failed
+    failure_reason TEXT,                -- set only if the move failed; one of the ast.MoveFailure_e codes
     parse_error    TEXT,                -- set only if the parser failed on this move
     CONSTRAINT action_check CHECK (action in ('STILL', 'SCOUT', 'N', 'NE', 'SE', 'S', 'SW', 'NW')),
     UNIQUE (clan_no, turn_no, unit_id, step_no)
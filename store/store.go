@@ -8,6 +8,8 @@ package store
 //go:generate sqlc generate
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"crypto/sha1"
 	"database/sql"
@@ -17,8 +19,10 @@ import (
 	"github.com/playbymail/tribal/adapters"
 	"github.com/playbymail/tribal/stdlib"
 	"github.com/playbymail/tribal/store/sqlc"
+	"io"
 	"log"
 	_ "modernc.org/sqlite"
+	"sort"
 	"strings"
 	"time"
 )
@@ -70,19 +74,185 @@ func (s *Store) CreateClan(clanNo int) (int, error) {
 }
 
 // CreateReport loads the report for the given turn.
-func (s *Store) CreateReport(rpt *tribal.ReportFile_t) error {
+// The original contents are compressed before they are stored.
+// The report file and its lines are created in a single transaction.
+// Returns the report ID or an error.
+//
+// Note: only the inventory and movement of the units in the report are stored.
+// The tiles that they moved through are created as needed, without details.
+func (s *Store) CreateReport(rpt *tribal.ReportFile_t) (int, error) {
 	// we never trust the client, so validate the input
 	if rpt == nil {
-		return errors.Join(ErrNoData, fmt.Errorf("report is nil"))
+		return 0, errors.Join(ErrNoData, fmt.Errorf("report is nil"))
 	} else if rpt.Hash == "" {
-		return errors.Join(ErrNoData, fmt.Errorf("report is missing hash"))
+		return 0, errors.Join(ErrNoData, fmt.Errorf("report is missing hash"))
+	} else if rpt.Original == nil {
+		return 0, errors.Join(ErrNoData, fmt.Errorf("report is missing original contents"))
+	}
+	if _, _, ok := adapters.TurnIdToYearMonth(rpt.Turn); !ok {
+		return 0, errors.Join(ErrInvalidTurnNo, fmt.Errorf("%d: invalid turn", rpt.Turn))
+	} else if len(rpt.Units) != 0 && !(1 <= rpt.Owner && rpt.Owner <= 999) {
+		return 0, errors.Join(ErrInvalidClanId, fmt.Errorf("%d: invalid clan", rpt.Owner))
+	}
+	original, err := compress(rpt.Original)
+	if err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.BeginTx(s.ctx, nil)
+	if err != nil {
+		return 0, errors.Join(ErrDatabase, err)
 	}
-	year, month, ok := adapters.TurnIdToYearMonth(rpt.Turn)
-	if !ok {
-		return errors.Join(ErrInvalidTurnNo, fmt.Errorf("%d: invalid turn", rpt.Turn))
+	defer tx.Rollback()
+	q := s.dbc.WithTx(tx)
+
+	id, err := q.CreateReportFile(s.ctx, sqlc.CreateReportFileParams{
+		Hash:        rpt.Hash,
+		Fingerprint: rpt.Fingerprint,
+		Name:        rpt.Name,
+		Original:    original,
+	})
+	if err != nil {
+		log.Printf("insert failed: %v", err)
+		if strings.HasPrefix(err.Error(), "constraint failed: UNIQUE constraint failed: report_files.hash ") {
+			return 0, ErrDuplicateReport
+		}
+		return 0, errors.Join(ErrDatabase, err)
 	}
-	_, _ = year, month
-	return ErrNotImplemented
+	for _, line := range rpt.Lines {
+		err := q.CreateReportLine(s.ctx, sqlc.CreateReportLineParams{
+			ReportID: id,
+			LineNo:   int64(line.No),
+			SourceNo: int64(line.Source),
+			Line:     line.Text,
+		})
+		if err != nil {
+			log.Printf("insert failed: %v", err)
+			return 0, errors.Join(ErrDatabase, err)
+		}
+	}
+
+	for _, unit := range rpt.Units {
+		if unit.Inventory == nil {
+			continue
+		} else if err := createInventory(s.ctx, q, rpt.Owner, rpt.Turn, unit.Id, unit.Inventory); err != nil {
+			return 0, err
+		}
+	}
+	for _, unit := range rpt.Units {
+		if err := createMoves(s.ctx, q, rpt.Owner, rpt.Turn, unit.Moves); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, errors.Join(ErrDatabase, err)
+	}
+	return int(id), nil
+}
+
+// createInventory adds the unit and its people and goods for the turn.
+func createInventory(ctx context.Context, q *sqlc.Queries, clan tribal.ClanId_t, turn tribal.TurnId_t, unitId tribal.UnitId_t, inv *tribal.Inventory_t) error {
+	err := q.CreateUnit(ctx, sqlc.CreateUnitParams{
+		ID:      string(unitId),
+		ClanNo:  int64(clan),
+		IsScout: isScout(unitId),
+	})
+	if err != nil {
+		log.Printf("insert failed: %v", err)
+		return errors.Join(ErrDatabase, err)
+	}
+	if p := inv.Population; p != nil {
+		err := q.CreateUnitPopulation(ctx, sqlc.CreateUnitPopulationParams{
+			ClanNo:    int64(clan),
+			TurnNo:    int64(turn),
+			UnitID:    string(unitId),
+			People:    int64(p.People),
+			Warriors:  int64(p.Warriors),
+			Actives:   int64(p.Actives),
+			Inactives: int64(p.Inactives),
+		})
+		if err != nil {
+			log.Printf("insert failed: %v", err)
+			return errors.Join(ErrDatabase, err)
+		}
+	}
+	for _, item := range inv.Items {
+		err := q.CreateUnitInventory(ctx, sqlc.CreateUnitInventoryParams{
+			ClanNo:   int64(clan),
+			TurnNo:   int64(turn),
+			UnitID:   string(unitId),
+			ItemCd:   item.Code,
+			Quantity: int64(item.Quantity),
+		})
+		if err != nil {
+			log.Printf("insert failed: %v", err)
+			return errors.Join(ErrDatabase, err)
+		}
+	}
+	return nil
+}
+
+// createMoves adds the steps of the units' movement for the turn.
+// The units and the tiles they move through are created if needed.
+// The failure reason is stored only for steps that failed.
+func createMoves(ctx context.Context, q *sqlc.Queries, clan tribal.ClanId_t, turn tribal.TurnId_t, moves []*tribal.Move_t) error {
+	for _, move := range moves {
+		err := q.CreateUnit(ctx, sqlc.CreateUnitParams{
+			ID:      string(move.Unit),
+			ClanNo:  int64(clan),
+			IsScout: isScout(move.Unit),
+		})
+		if err != nil {
+			log.Printf("insert failed: %v", err)
+			return errors.Join(ErrDatabase, err)
+		}
+		from, err := tileId(ctx, q, move.From)
+		if err != nil {
+			return err
+		}
+		to, err := tileId(ctx, q, move.To)
+		if err != nil {
+			return err
+		}
+		err = q.CreateMove(ctx, sqlc.CreateMoveParams{
+			ClanNo:        int64(clan),
+			TurnNo:        int64(turn),
+			UnitID:        string(move.Unit),
+			StepNo:        int64(move.Step),
+			StartingTile:  from,
+			Action:        move.Action,
+			EndingTile:    to,
+			TerrainCd:     move.Terrain,
+			FailureReason: sql.NullString{String: move.Failure, Valid: move.Failure != ""},
+		})
+		if err != nil {
+			log.Printf("insert failed: %v", err)
+			return errors.Join(ErrDatabase, err)
+		}
+	}
+	return nil
+}
+
+// tileId returns the id of the tile at the location, creating the tile if needed.
+func tileId(ctx context.Context, q *sqlc.Queries, c tribal.Coordinates_t) (int64, error) {
+	id, err := q.GetTileId(ctx, sqlc.GetTileIdParams{Grid: c.Grid, Row: int64(c.Row), Col: int64(c.Col)})
+	if errors.Is(err, sql.ErrNoRows) {
+		id, err = q.CreateTile(ctx, sqlc.CreateTileParams{Grid: c.Grid, Row: int64(c.Row), Col: int64(c.Col)})
+	}
+	if err != nil {
+		log.Printf("insert failed: %v", err)
+		return 0, errors.Join(ErrDatabase, err)
+	}
+	return id, nil
+}
+
+// isScout returns 1 if the unit is a scout, 0 otherwise.
+func isScout(unitId tribal.UnitId_t) int64 {
+	if id := string(unitId); len(id) > 4 && id[len(id)-2] == 's' {
+		return 1
+	}
+	return 0
 }
 
 // CreateTurn creates a new turn in the database.
@@ -120,13 +290,24 @@ type ReportFileMeta_t struct {
 }
 
 // GetReportByHash returns the report for the given hash.
+// Files that were linked to a report return that report.
 // Returns nil if the report does not exist.
 func (s *Store) GetReportByHash(hash string) (*ReportFileMeta_t, error) {
 	row, err := s.dbc.GetReportByHash(s.ctx, hash)
-	if err != nil {
+	if errors.Is(err, sql.ErrNoRows) {
+		link, err := s.dbc.GetReportLinkByHash(s.ctx, hash)
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
+		} else if err != nil {
+			return nil, errors.Join(ErrDatabase, err)
 		}
+		return &ReportFileMeta_t{
+			Id:        int(link.ID),
+			Hash:      hash,
+			Name:      link.Name,
+			CreatedAt: time.Unix(link.CreatedAt, 0).UTC(),
+		}, nil
+	} else if err != nil {
 		return nil, errors.Join(ErrDatabase, err)
 	}
 	return &ReportFileMeta_t{
@@ -137,6 +318,77 @@ func (s *Store) GetReportByHash(hash string) (*ReportFileMeta_t, error) {
 	}, nil
 }
 
+// GetReportByFingerprint returns the first report with the given fingerprint.
+// Returns nil if there is no such report or if the fingerprint is empty.
+func (s *Store) GetReportByFingerprint(fingerprint string) (*ReportFileMeta_t, error) {
+	if fingerprint == "" {
+		return nil, nil
+	}
+	row, err := s.dbc.GetReportByFingerprint(s.ctx, fingerprint)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Join(ErrDatabase, err)
+	}
+	return &ReportFileMeta_t{
+		Id:        int(row.ID),
+		Hash:      row.Hash,
+		Name:      row.Name,
+		CreatedAt: time.Unix(row.CreatedAt, 0).UTC(),
+	}, nil
+}
+
+// LinkReport links a file to an existing report instead of loading it again.
+// The hash is the hash of the file, so that loading the file again is caught as a duplicate.
+func (s *Store) LinkReport(id int, hash, name string) error {
+	if hash == "" {
+		return errors.Join(ErrNoData, fmt.Errorf("link is missing hash"))
+	}
+	err := s.dbc.CreateReportLink(s.ctx, sqlc.CreateReportLinkParams{
+		ReportID: int64(id),
+		Hash:     hash,
+		Name:     name,
+	})
+	if err != nil {
+		log.Printf("insert failed: %v", err)
+		if strings.HasPrefix(err.Error(), "constraint failed: UNIQUE constraint failed: report_links.hash ") {
+			return ErrDuplicateReport
+		}
+		return errors.Join(ErrDatabase, err)
+	}
+	return nil
+}
+
+// GetReportLines returns the normalized lines of the report, in order.
+// Returns an empty list if the report does not exist.
+func (s *Store) GetReportLines(id int) ([]*tribal.ReportLine_t, error) {
+	rows, err := s.dbc.GetReportLines(s.ctx, int64(id))
+	if err != nil {
+		return nil, errors.Join(ErrDatabase, err)
+	}
+	var lines []*tribal.ReportLine_t
+	for _, row := range rows {
+		lines = append(lines, &tribal.ReportLine_t{
+			No:     int(row.LineNo),
+			Source: int(row.SourceNo),
+			Text:   row.Line,
+		})
+	}
+	return lines, nil
+}
+
+// GetReportOriginal returns the original contents of the report.
+// If the report does not exist, it returns an error.
+func (s *Store) GetReportOriginal(id int) ([]byte, error) {
+	original, err := s.dbc.GetReportOriginal(s.ctx, int64(id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, errors.Join(ErrDatabase, err)
+	}
+	return decompress(original)
+}
+
 // GetTurnNo returns the turn number for the given year and month.
 // If the turn does not exist, it returns an error.
 func (s *Store) GetTurnNo(year, month int) (int, error) {
@@ -157,7 +409,101 @@ func (s *Store) GetTurnNo(year, month int) (int, error) {
 	return int(turnNo), nil
 }
 
+// GetUnitInventories returns the people and goods that the unit had at the
+// end of every turn that we have a report for, in turn order.
+func (s *Store) GetUnitInventories(clan tribal.ClanId_t, unitId tribal.UnitId_t) ([]*tribal.Inventory_t, error) {
+	populations, err := s.dbc.GetUnitPopulations(s.ctx, sqlc.GetUnitPopulationsParams{
+		ClanNo: int64(clan),
+		UnitID: string(unitId),
+	})
+	if err != nil {
+		return nil, errors.Join(ErrDatabase, err)
+	}
+	items, err := s.dbc.GetUnitInventories(s.ctx, sqlc.GetUnitInventoriesParams{
+		ClanNo: int64(clan),
+		UnitID: string(unitId),
+	})
+	if err != nil {
+		return nil, errors.Join(ErrDatabase, err)
+	}
+
+	var list []*tribal.Inventory_t
+	byTurn := map[tribal.TurnId_t]*tribal.Inventory_t{}
+	inventory := func(turn tribal.TurnId_t) *tribal.Inventory_t {
+		inv, ok := byTurn[turn]
+		if !ok {
+			inv = &tribal.Inventory_t{Turn: turn, Unit: unitId}
+			byTurn[turn] = inv
+			list = append(list, inv)
+		}
+		return inv
+	}
+	for _, row := range populations {
+		inventory(tribal.TurnId_t(row.TurnNo)).Population = &tribal.Population_t{
+			People:    int(row.People),
+			Warriors:  int(row.Warriors),
+			Actives:   int(row.Actives),
+			Inactives: int(row.Inactives),
+		}
+	}
+	for _, row := range items {
+		inv := inventory(tribal.TurnId_t(row.TurnNo))
+		inv.Items = append(inv.Items, &tribal.Item_t{Code: row.ItemCd, Quantity: int(row.Quantity)})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Turn < list[j].Turn
+	})
+	return list, nil
+}
+
+// GetUnitMoves returns the steps of the unit's movement for the turn, in order.
+func (s *Store) GetUnitMoves(clan tribal.ClanId_t, turn tribal.TurnId_t, unitId tribal.UnitId_t) ([]*tribal.Move_t, error) {
+	rows, err := s.dbc.GetUnitMoves(s.ctx, sqlc.GetUnitMovesParams{
+		ClanNo: int64(clan),
+		TurnNo: int64(turn),
+		UnitID: string(unitId),
+	})
+	if err != nil {
+		return nil, errors.Join(ErrDatabase, err)
+	}
+	var list []*tribal.Move_t
+	for _, row := range rows {
+		list = append(list, &tribal.Move_t{
+			Unit:    unitId,
+			Step:    int(row.StepNo),
+			From:    tribal.Coordinates_t{Grid: row.FromGrid, Col: int(row.FromCol), Row: int(row.FromRow)},
+			Action:  row.Action,
+			To:      tribal.Coordinates_t{Grid: row.ToGrid, Col: int(row.ToCol), Row: int(row.ToRow)},
+			Terrain: row.TerrainCd,
+			Failure: row.FailureReason.String,
+		})
+	}
+	return list, nil
+}
+
 // Hash returns the SHA1 hash of the given data.
 func Hash(data []byte) string {
 	return fmt.Sprintf("%x", sha1.Sum(data))
 }
+
+// compress returns the data compressed with gzip.
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	} else if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress returns the data uncompressed with gzip.
+func decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/playbymail/tribal"
+	"github.com/playbymail/tribal/store/sqlc"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestStore returns a store backed by an in-memory database that is
+// created from the schema. The database has a single clan, 0987.
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+	schema, err := os.ReadFile(filepath.Join("sqlc", "schema.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every connection to an in-memory database gets its own database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+	if _, err := db.Exec(string(schema)); err != nil {
+		t.Fatalf("schema: %v", err)
+	}
+	s := &Store{db: db, dbc: sqlc.New(db), ctx: context.Background()}
+	if _, err := s.CreateClan(987); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// count returns the number of rows in the table.
+func count(t *testing.T, s *Store, table string) int {
+	t.Helper()
+	var n int
+	if err := s.db.QueryRow("SELECT COUNT(*) FROM " + table).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestCreateReportMoves(t *testing.T) {
+	s := newTestStore(t)
+
+	kp0608 := tribal.Coordinates_t{Grid: "KP", Col: 6, Row: 8}
+	kp0607 := tribal.Coordinates_t{Grid: "KP", Col: 6, Row: 7}
+	rpt := &tribal.ReportFile_t{
+		Owner:    987,
+		Name:     "0900-05.0987.report.txt",
+		Turn:     5,
+		Hash:     Hash([]byte("moves")),
+		Original: []byte("moves"),
+		Units: []*tribal.Unit_t{{
+			Id: "0987",
+			Moves: []*tribal.Move_t{
+				{Unit: "0987s1", Step: 1, From: kp0608, Action: "N", To: kp0607, Terrain: "GH"},
+				{Unit: "0987s1", Step: 2, From: kp0607, Action: "N", To: kp0607, Terrain: "GH", Failure: "NOT_ENOUGH_MPS"},
+			},
+		}},
+	}
+	if _, err := s.CreateReport(rpt); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := count(t, s, "moves"); got != 2 {
+		t.Errorf("moves: want 2, got %d", got)
+	}
+	if got := count(t, s, "tiles"); got != 2 {
+		t.Errorf("tiles: want 2, got %d", got)
+	}
+	if got := count(t, s, "moves WHERE failure_reason IS NULL"); got != 1 {
+		t.Errorf("moves without failure: want 1, got %d", got)
+	}
+
+	moves, err := s.GetUnitMoves(987, 5, "0987s1")
+	if err != nil {
+		t.Fatal(err)
+	} else if len(moves) != 2 {
+		t.Fatalf("moves: want 2, got %d", len(moves))
+	}
+	for n, want := range rpt.Units[0].Moves {
+		if got := moves[n]; *got != *want {
+			t.Errorf("move %d: want %+v, got %+v", n+1, *want, *got)
+		}
+	}
+}
+
+func TestCreateReport(t *testing.T) {
+	s := newTestStore(t)
+
+	original := []byte("Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0608)\r\n\r\nCurrent Turn 900-05 (#5), Summer, FINE\r\n")
+	rpt := &tribal.ReportFile_t{
+		Owner:       987,
+		Name:        "0900-05.0987.report.txt",
+		Turn:        5,
+		Hash:        Hash(original),
+		Original:    original,
+		Fingerprint: "fingerprint",
+		Lines: []*tribal.ReportLine_t{
+			{No: 1, Source: 1, Text: "Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0608)"},
+			{No: 2, Source: 3, Text: "Current Turn 900-05 (#5), Summer, FINE"},
+		},
+		Units: []*tribal.Unit_t{{
+			Id: "0987",
+			Inventory: &tribal.Inventory_t{
+				Turn:       5,
+				Unit:       "0987",
+				Population: &tribal.Population_t{People: 100, Warriors: 10, Actives: 60, Inactives: 30},
+				Items: []*tribal.Item_t{
+					{Code: "ADZE", Quantity: 5},
+					{Code: "ARROWS", Quantity: 200},
+				},
+			},
+		}},
+	}
+	id, err := s.CreateReport(rpt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		table string
+		want  int
+	}{
+		{"report_files", 1},
+		{"report_lines", 2},
+		{"units", 1},
+		{"unit_populations", 1},
+		{"unit_inventories", 2},
+	} {
+		if got := count(t, s, tc.table); got != tc.want {
+			t.Errorf("%s: want %d, got %d", tc.table, tc.want, got)
+		}
+	}
+
+	lines, err := s.GetReportLines(id)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(lines) != len(rpt.Lines) {
+		t.Fatalf("lines: want %d, got %d", len(rpt.Lines), len(lines))
+	}
+	for n, want := range rpt.Lines {
+		if got := lines[n]; *got != *want {
+			t.Errorf("line %d: want %+v, got %+v", n+1, *want, *got)
+		}
+	}
+	if got, err := s.GetReportOriginal(id); err != nil {
+		t.Fatal(err)
+	} else if string(got) != string(original) {
+		t.Errorf("original: want %q, got %q", original, got)
+	}
+
+	// loading the same file again is an error
+	if _, err := s.CreateReport(rpt); !errors.Is(err, ErrDuplicateReport) {
+		t.Errorf("duplicate: want %v, got %v", ErrDuplicateReport, err)
+	}
+}
+
+func TestLinkReport(t *testing.T) {
+	s := newTestStore(t)
+
+	original := []byte("Tribe 0987, , Current Hex = KP 0608, (Previous Hex = KP 0608)\n")
+	id, err := s.CreateReport(&tribal.ReportFile_t{
+		Name:        "0900-05.0987.report.docx",
+		Turn:        5,
+		Hash:        Hash(original),
+		Original:    original,
+		Fingerprint: "fingerprint",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if row, err := s.GetReportByFingerprint("fingerprint"); err != nil {
+		t.Fatal(err)
+	} else if row == nil || row.Id != id {
+		t.Fatalf("fingerprint: want report %d, got %+v", id, row)
+	}
+	if row, err := s.GetReportByFingerprint("other"); err != nil || row != nil {
+		t.Errorf("other fingerprint: want nil, got %+v, %v", row, err)
+	}
+
+	resaved := Hash([]byte("re-saved"))
+	if err := s.LinkReport(id, resaved, "0900-05.0987.report.txt"); err != nil {
+		t.Fatal(err)
+	}
+	if row, err := s.GetReportByHash(resaved); err != nil {
+		t.Fatal(err)
+	} else if row == nil || row.Id != id || row.Name != "0900-05.0987.report.docx" {
+		t.Errorf("link: want report %d, got %+v", id, row)
+	}
+	if got := count(t, s, "report_links"); got != 1 {
+		t.Errorf("report_links: want 1, got %d", got)
+	}
+
+	// linking the same file again is an error
+	if err := s.LinkReport(id, resaved, "copy.txt"); !errors.Is(err, ErrDuplicateReport) {
+		t.Errorf("duplicate: want %v, got %v", ErrDuplicateReport, err)
+	}
+}
@@ -68,6 +68,15 @@ func (e Terrain_e) IsSwamp() bool {
 	return e == Swamp
 }
 
+// IsUnknown returns true if the terrain is a group, like "unknown water,"
+// rather than a specific terrain.
+func (e Terrain_e) IsUnknown() bool {
+	return e == UnknownJungleSwamp ||
+		e == UnknownLand ||
+		e == UnknownMountain ||
+		e == UnknownWater
+}
+
 // MarshalJSON implements the json.Marshaler interface.
 func (e Terrain_e) MarshalJSON() ([]byte, error) {
 	return json.Marshal(EnumToString[e])
@@ -283,6 +292,40 @@ var (
 		"tundra":                 Tundra,
 	}
 
+	// EnumToLongName is the map for writing terrain names in status lines
+	// and failed moves. The names are the ones the GM uses in the report.
+	// Terrain that is never reported by name is not listed.
+	EnumToLongName = map[Terrain_e]string{
+		Alps:                 "ALPS",
+		AridHills:            "ARID HILLS",
+		AridTundra:           "ARID TUNDRA",
+		BrushFlat:            "BRUSH FLAT",
+		BrushHills:           "BRUSH HILLS",
+		ConiferHills:         "CONIFER HILLS",
+		Deciduous:            "DECIDUOUS",
+		DeciduousHills:       "DECIDUOUS HILLS",
+		Desert:               "DESERT",
+		GrassyHills:          "GRASSY HILLS",
+		HighSnowyMountains:   "HIGH SNOWY MOUNTAINS",
+		Jungle:               "JUNGLE",
+		JungleHills:          "JUNGLE HILLS",
+		Lake:                 "LAKE",
+		LowAridMountains:     "LOW ARID MOUNTAINS",
+		LowConiferMountains:  "LOW CONIFER MOUNTAINS",
+		LowJungleMountains:   "LOW JUNGLE MOUNTAINS",
+		LowSnowyMountains:    "LOW SNOWY MOUNTAINS",
+		LowVolcanicMountains: "LOW VOLCANIC MOUNTAINS",
+		Ocean:                "OCEAN",
+		PlateauGrassyHills:   "PLATEAU GRASSY HILLS",
+		PolarIce:             "POLAR ICE",
+		Prairie:              "PRAIRIE",
+		PrairiePlateau:       "PLATEAU PRAIRIE",
+		RockyHills:           "ROCKY HILLS",
+		SnowyHills:           "SNOWY HILLS",
+		Swamp:                "SWAMP",
+		Tundra:               "TUNDRA",
+	}
+
 	// NeighborCodes is the map for matching terrain names in neighboring tiles.
 	// Not all terrain types are listed here, only those that are actually observable in the game.
 	NeighborCodes = map[string]Terrain_e{
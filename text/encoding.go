@@ -0,0 +1,136 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package text
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Encoding_e is the character encoding of a plain text report.
+type Encoding_e int
+
+const (
+	UTF8 Encoding_e = iota
+	UTF16LE
+	UTF16BE
+	Windows1252
+)
+
+// String implements the fmt.Stringer interface.
+func (e Encoding_e) String() string {
+	if str, ok := EncodingToString[e]; ok {
+		return str
+	}
+	return fmt.Sprintf("Encoding(%d)", int(e))
+}
+
+var (
+	// EncodingToString is a helper map for printing the enum
+	EncodingToString = map[Encoding_e]string{
+		UTF8:        "UTF-8",
+		UTF16LE:     "UTF-16LE",
+		UTF16BE:     "UTF-16BE",
+		Windows1252: "Windows-1252",
+	}
+)
+
+var (
+	bomUTF8    = []byte{0xEF, 0xBB, 0xBF}
+	bomUTF16LE = []byte{0xFF, 0xFE}
+	bomUTF16BE = []byte{0xFE, 0xFF}
+)
+
+// DetectEncoding guesses the encoding of a plain text report.
+// A byte order mark wins. Otherwise, text with NUL bytes in every other
+// position is UTF-16, valid UTF-8 is UTF-8, and anything else is assumed
+// to be Windows-1252, which is what Notepad and friends save on Windows.
+// Also returns the length of the byte order mark, if any.
+func DetectEncoding(data []byte) (enc Encoding_e, bom int) {
+	switch {
+	case bytes.HasPrefix(data, bomUTF8):
+		return UTF8, len(bomUTF8)
+	case bytes.HasPrefix(data, bomUTF16LE):
+		return UTF16LE, len(bomUTF16LE)
+	case bytes.HasPrefix(data, bomUTF16BE):
+		return UTF16BE, len(bomUTF16BE)
+	}
+	if enc, ok := detectUTF16(data); ok {
+		return enc, 0
+	} else if utf8.Valid(data) {
+		return UTF8, 0
+	}
+	return Windows1252, 0
+}
+
+// detectUTF16 looks for UTF-16 without a byte order mark. Reports are mostly
+// ASCII, so the high byte of nearly every code unit is NUL.
+func detectUTF16(data []byte) (Encoding_e, bool) {
+	n := min(len(data), 1024) &^ 1
+	if n == 0 {
+		return UTF8, false
+	}
+	var even, odd int
+	for i := 0; i < n; i += 2 {
+		if data[i] == 0 {
+			even++
+		}
+		if data[i+1] == 0 {
+			odd++
+		}
+	}
+	units := n / 2
+	if odd*10 >= units*9 && even == 0 {
+		return UTF16LE, true
+	} else if even*10 >= units*9 && odd == 0 {
+		return UTF16BE, true
+	}
+	return UTF8, false
+}
+
+// Decode returns the report transcoded to UTF-8 with any byte order mark removed.
+// Input that is already UTF-8 is returned as is.
+func Decode(data []byte) []byte {
+	enc, bom := DetectEncoding(data)
+	data = data[bom:]
+	switch enc {
+	case UTF16LE:
+		return decodeUTF16(data, binary.LittleEndian)
+	case UTF16BE:
+		return decodeUTF16(data, binary.BigEndian)
+	case Windows1252:
+		return decodeWindows1252(data)
+	}
+	return data
+}
+
+// decodeUTF16 transcodes UTF-16 to UTF-8.
+// An odd trailing byte is dropped and unpaired surrogates become U+FFFD.
+func decodeUTF16(data []byte, order binary.ByteOrder) []byte {
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		units[i] = order.Uint16(data[2*i:])
+	}
+	output := make([]byte, 0, len(units))
+	for _, r := range utf16.Decode(units) {
+		output = utf8.AppendRune(output, r)
+	}
+	return output
+}
+
+// decodeWindows1252 transcodes Windows-1252 to UTF-8.
+// Smart quotes, dashes and accented letters map to their Unicode characters.
+func decodeWindows1252(data []byte) []byte {
+	output := make([]byte, 0, len(data)+len(data)/8)
+	for _, b := range data {
+		if b < utf8.RuneSelf {
+			output = append(output, b)
+		} else {
+			output = utf8.AppendRune(output, DecodeWindows1252(b))
+		}
+	}
+	return output
+}
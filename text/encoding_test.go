@@ -0,0 +1,54 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package text_test
+
+import (
+	"encoding/binary"
+	"github.com/playbymail/tribal/text"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestDecode(t *testing.T) {
+	const want = "Tribe 0987, , Current Hex = KP 0608\nCafé “Los Angeles” – Zürich"
+	utf16le := func(bom bool) []byte {
+		var b []byte
+		if bom {
+			b = append(b, 0xFF, 0xFE)
+		}
+		for _, u := range utf16.Encode([]rune(want)) {
+			b = binary.LittleEndian.AppendUint16(b, u)
+		}
+		return b
+	}
+	utf16be := func(bom bool) []byte {
+		var b []byte
+		if bom {
+			b = append(b, 0xFE, 0xFF)
+		}
+		for _, u := range utf16.Encode([]rune(want)) {
+			b = binary.BigEndian.AppendUint16(b, u)
+		}
+		return b
+	}
+	for _, tc := range []struct {
+		name  string
+		input []byte
+		enc   text.Encoding_e
+	}{
+		{"utf-8", []byte(want), text.UTF8},
+		{"utf-8 bom", append([]byte{0xEF, 0xBB, 0xBF}, want...), text.UTF8},
+		{"utf-16le bom", utf16le(true), text.UTF16LE},
+		{"utf-16be bom", utf16be(true), text.UTF16BE},
+		{"utf-16le", utf16le(false), text.UTF16LE},
+		{"utf-16be", utf16be(false), text.UTF16BE},
+		{"windows-1252", []byte("Tribe 0987, , Current Hex = KP 0608\nCaf\xe9 \x93Los Angeles\x94 \x96 Z\xfcrich"), text.Windows1252},
+	} {
+		if enc, _ := text.DetectEncoding(tc.input); enc != tc.enc {
+			t.Errorf("%s: encoding: want %v, got %v", tc.name, tc.enc, enc)
+		}
+		if got := string(text.Decode(tc.input)); got != want {
+			t.Errorf("%s: want %q, got %q", tc.name, want, got)
+		}
+	}
+}
@@ -18,8 +18,9 @@ func ReadFile(path string) ([][]byte, error) {
 }
 
 // Read reads a turn report from a byte slice and returns the contents as a slice of lines.
-// The input is assumed to be plain text.
+// The input is assumed to be plain text, in UTF-8, UTF-16 or Windows-1252.
+// It is transcoded to UTF-8 before splitting.
 func Read(data []byte) ([][]byte, error) {
 	// normalize line endings before splitting.
-	return bytes.Split(norm.LineEndings(data), []byte{'\n'}), nil
+	return bytes.Split(norm.LineEndings(Decode(data)), []byte{'\n'}), nil
 }
@@ -0,0 +1,23 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package text
+
+// DecodeWindows1252 returns the character for a byte in the Windows-1252 code page.
+// Bytes outside 0x80 through 0x9F are the same as ISO-8859-1.
+func DecodeWindows1252(b byte) rune {
+	if 0x80 <= b && b <= 0x9F {
+		if r := windows1252[b-0x80]; r != 0 {
+			return r
+		}
+	}
+	return rune(b)
+}
+
+// windows1252 maps 0x80 through 0x9F, which differ from ISO-8859-1.
+// Zero entries are undefined and passed through unchanged.
+var windows1252 = [32]rune{
+	0x20AC, 0, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0, 0x017D, 0,
+	0, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0, 0x017E, 0x0178,
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package tiles
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Confidence_e is an enum for how much we trust the terrain of a tile.
+// An observation never replaces one with a higher confidence.
+type Confidence_e int
+
+const (
+	NoConfidence       Confidence_e = iota
+	NeighborConfidence              // seen from an adjacent tile
+	FleetConfidence                 // seen by one of our fleets passing through or by
+	AllianceConfidence              // seen by another clan's unit
+	VisitConfidence                 // seen by one of our units in the tile
+	OverrideConfidence              // set by the player
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e Confidence_e) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ConfidenceToString[e])
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *Confidence_e) UnmarshalJSON(data []byte) error {
+	var s string
+	var ok bool
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	} else if *e, ok = StringToConfidence[s]; !ok {
+		return fmt.Errorf("invalid Confidence %q", s)
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (e Confidence_e) String() string {
+	if str, ok := ConfidenceToString[e]; ok {
+		return str
+	}
+	return fmt.Sprintf("Confidence(%d)", int(e))
+}
+
+var (
+	// ConfidenceToString is a helper map for marshalling the enum
+	ConfidenceToString = map[Confidence_e]string{
+		NoConfidence:       "",
+		NeighborConfidence: "neighbor",
+		FleetConfidence:    "fleet",
+		AllianceConfidence: "alliance",
+		VisitConfidence:    "visit",
+		OverrideConfidence: "override",
+	}
+	// StringToConfidence is a helper map for unmarshalling the enum
+	StringToConfidence = map[string]Confidence_e{
+		"":         NoConfidence,
+		"neighbor": NeighborConfidence,
+		"fleet":    FleetConfidence,
+		"alliance": AllianceConfidence,
+		"visit":    VisitConfidence,
+		"override": OverrideConfidence,
+	}
+)
@@ -0,0 +1,99 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package tiles
+
+import (
+	"fmt"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/terrain"
+	"sort"
+)
+
+// Conflict_t is a set of facts about the same thing in a tile, observed in
+// the same turn, that don't agree. Facts lists every observation of the
+// thing in that turn, including the ones that agree with each other.
+type Conflict_t struct {
+	Point       Point_t               `json:"-"`
+	Coordinates ast.Coordinates_t     `json:"coordinates"`
+	Turn        ast.TurnId_t          `json:"turn"`
+	Kind        Fact_e                `json:"kind"`
+	Direction   direction.Direction_e `json:"direction,omitempty"`
+	Facts       []*Fact_t             `json:"facts"`
+}
+
+func (c *Conflict_t) String() string {
+	if c.Direction != direction.None {
+		return fmt.Sprintf("%s: turn %d: %s %s", c.Coordinates, c.Turn, c.Kind, c.Direction)
+	}
+	return fmt.Sprintf("%s: turn %d: %s", c.Coordinates, c.Turn, c.Kind)
+}
+
+// Conflicts returns the things that sources disagree on within a turn,
+// sorted by location, then turn, kind and direction.
+//
+// A terrain group (like "unknown land") doesn't disagree with a specific
+// terrain from the same source. The specific terrain refines it. A group from
+// another source does disagree; a fleet that reports "unknown water" where a
+// unit found ocean is listed.
+func (m *Map_t) Conflicts() []*Conflict_t {
+	type key_t struct {
+		turn ast.TurnId_t
+		kind Fact_e
+		dir  direction.Direction_e
+	}
+	var list []*Conflict_t
+	for _, t := range m.Sorted() {
+		groups := map[key_t][]*Fact_t{}
+		var keys []key_t
+		for _, f := range t.Facts {
+			k := key_t{turn: f.Turn, kind: f.Kind, dir: f.Direction}
+			if _, ok := groups[k]; !ok {
+				keys = append(keys, k)
+			}
+			groups[k] = append(groups[k], f)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].turn != keys[j].turn {
+				return keys[i].turn < keys[j].turn
+			} else if keys[i].kind != keys[j].kind {
+				return keys[i].kind < keys[j].kind
+			}
+			return keys[i].dir < keys[j].dir
+		})
+		for _, k := range keys {
+			if facts := groups[k]; disagree(k.kind, facts) {
+				list = append(list, &Conflict_t{
+					Point:       t.Point,
+					Coordinates: t.Point.Coordinates(),
+					Turn:        k.turn,
+					Kind:        k.kind,
+					Direction:   k.dir,
+					Facts:       facts,
+				})
+			}
+		}
+	}
+	return list
+}
+
+// disagree returns true if the facts have more than one value.
+// A terrain group is refined by a specific terrain from the same source.
+func disagree(kind Fact_e, facts []*Fact_t) bool {
+	specific := map[Source_e]bool{}
+	if kind == TerrainFact {
+		for _, f := range facts {
+			if ter, ok := terrain.StringToEnum[f.Value]; ok && !ter.IsUnknown() {
+				specific[f.Source] = true
+			}
+		}
+	}
+	values := map[string]bool{}
+	for _, f := range facts {
+		if ter, ok := terrain.StringToEnum[f.Value]; kind == TerrainFact && ok && ter.IsUnknown() && specific[f.Source] {
+			continue
+		}
+		values[f.Value] = true
+	}
+	return len(values) > 1
+}
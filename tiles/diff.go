@@ -0,0 +1,234 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package tiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/terrain"
+	"sort"
+)
+
+// Change_e is an enum for the kinds of changes between two maps.
+type Change_e int
+
+const (
+	NoChange Change_e = iota
+	NewTile
+	TerrainRefined
+	NewSettlement
+	VanishedSettlement
+	NewBorder
+	NewPassage
+	NewResource
+	ForeignUnitAppeared
+	ForeignUnitVanished
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e Change_e) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ChangeToString[e])
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *Change_e) UnmarshalJSON(data []byte) error {
+	var s string
+	var ok bool
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	} else if *e, ok = StringToChange[s]; !ok {
+		return fmt.Errorf("invalid Change %q", s)
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (e Change_e) String() string {
+	if str, ok := ChangeToString[e]; ok {
+		return str
+	}
+	return fmt.Sprintf("Change(%d)", int(e))
+}
+
+var (
+	// ChangeToString is a helper map for marshalling the enum
+	ChangeToString = map[Change_e]string{
+		NoChange:            "",
+		NewTile:             "new tile",
+		TerrainRefined:      "terrain refined",
+		NewSettlement:       "new settlement",
+		VanishedSettlement:  "vanished settlement",
+		NewBorder:           "new border",
+		NewPassage:          "new passage",
+		NewResource:         "new resource",
+		ForeignUnitAppeared: "foreign unit appeared",
+		ForeignUnitVanished: "foreign unit vanished",
+	}
+	// StringToChange is a helper map for unmarshalling the enum
+	StringToChange = map[string]Change_e{
+		"":                      NoChange,
+		"new tile":              NewTile,
+		"terrain refined":       TerrainRefined,
+		"new settlement":        NewSettlement,
+		"vanished settlement":   VanishedSettlement,
+		"new border":            NewBorder,
+		"new passage":           NewPassage,
+		"new resource":          NewResource,
+		"foreign unit appeared": ForeignUnitAppeared,
+		"foreign unit vanished": ForeignUnitVanished,
+	}
+)
+
+// Change_t is a single difference between two maps.
+type Change_t struct {
+	Kind        Change_e              `json:"kind"`
+	Point       Point_t               `json:"-"`
+	Coordinates ast.Coordinates_t     `json:"coordinates"`
+	Direction   direction.Direction_e `json:"direction,omitempty"`
+	Unit        ast.UnitId_t          `json:"unit,omitempty"`
+	Before      string                `json:"before,omitempty"`
+	After       string                `json:"after,omitempty"`
+}
+
+func (c *Change_t) String() string {
+	switch c.Kind {
+	case NewTile:
+		return fmt.Sprintf("%s: %s: %s", c.Coordinates, c.Kind, c.After)
+	case TerrainRefined:
+		return fmt.Sprintf("%s: %s: %s -> %s", c.Coordinates, c.Kind, c.Before, c.After)
+	case NewSettlement:
+		return fmt.Sprintf("%s: %s: %s", c.Coordinates, c.Kind, c.After)
+	case VanishedSettlement:
+		return fmt.Sprintf("%s: %s: %s", c.Coordinates, c.Kind, c.Before)
+	case NewBorder, NewPassage:
+		if c.Before != "" {
+			return fmt.Sprintf("%s: %s: %s %s (was %s)", c.Coordinates, c.Kind, c.After, c.Direction, c.Before)
+		}
+		return fmt.Sprintf("%s: %s: %s %s", c.Coordinates, c.Kind, c.After, c.Direction)
+	case NewResource:
+		return fmt.Sprintf("%s: %s: %s", c.Coordinates, c.Kind, c.After)
+	case ForeignUnitAppeared, ForeignUnitVanished:
+		return fmt.Sprintf("%s: %s: %s", c.Coordinates, c.Kind, c.Unit)
+	}
+	return fmt.Sprintf("%s: %s", c.Coordinates, c.Kind)
+}
+
+// Diff_t is the list of changes between the map at the end of two turns.
+type Diff_t struct {
+	From    ast.TurnId_t `json:"from"`
+	To      ast.TurnId_t `json:"to"`
+	Changes []*Change_t  `json:"changes"`
+}
+
+// DiffTurns builds the map at the end of each turn and returns the changes
+// between them. Tiles that we knew about on the from turn but didn't see
+// again are not changes; the map remembers what we knew.
+func DiffTurns(units []*ast.Unit_t, from, to ast.TurnId_t) *Diff_t {
+	d := Diff(BuildThrough(units, from), BuildThrough(units, to))
+	d.From, d.To = from, to
+	return d
+}
+
+// Diff returns the changes from one map to another.
+// The changes are sorted by location, then kind.
+func Diff(from, to *Map_t) *Diff_t {
+	d := &Diff_t{From: from.Turn, To: to.Turn, Changes: []*Change_t{}}
+	add := func(kind Change_e, p Point_t) *Change_t {
+		c := &Change_t{Kind: kind, Point: p, Coordinates: p.Coordinates()}
+		d.Changes = append(d.Changes, c)
+		return c
+	}
+
+	for _, t := range to.Sorted() {
+		was, ok := from.Tiles[t.Point]
+		if !ok {
+			c := add(NewTile, t.Point)
+			c.After = terrain.EnumToString[t.Terrain]
+			was = &Tile_t{}
+		} else if was.Terrain != t.Terrain && t.Terrain != terrain.Blank {
+			c := add(TerrainRefined, t.Point)
+			c.Before, c.After = terrain.EnumToString[was.Terrain], terrain.EnumToString[t.Terrain]
+		}
+		if t.HexName != nil && (was.HexName == nil || *was.HexName != *t.HexName) {
+			c := add(NewSettlement, t.Point)
+			c.After = t.HexName.Name
+			if was.HexName != nil {
+				c.Before = was.HexName.Name
+			}
+		} else if t.HexName == nil && was.HexName != nil {
+			c := add(VanishedSettlement, t.Point)
+			c.Before = was.HexName.Name
+		}
+		for _, dir := range direction.Directions {
+			if b := t.Borders[dir]; b != was.Borders[dir] && b != 0 {
+				c := add(NewBorder, t.Point)
+				c.Direction, c.After = dir, b.String()
+				if prior := was.Borders[dir]; prior != 0 {
+					c.Before = prior.String()
+				}
+			}
+			if p := t.Passages[dir]; p != was.Passages[dir] && p != 0 {
+				c := add(NewPassage, t.Point)
+				c.Direction, c.After = dir, p.String()
+				if prior := was.Passages[dir]; prior != 0 {
+					c.Before = prior.String()
+				}
+			}
+		}
+		for _, r := range t.Resources {
+			found := false
+			for _, have := range was.Resources {
+				found = found || have == r
+			}
+			if !found {
+				c := add(NewResource, t.Point)
+				c.After = r.String()
+			}
+		}
+	}
+
+	// foreign units are compared by id, not by tile, so that a unit that
+	// moved from one tile to another isn't reported as vanishing.
+	before, after := foreignUnits(from), foreignUnits(to)
+	for id, p := range after {
+		if _, ok := before[id]; !ok {
+			add(ForeignUnitAppeared, p).Unit = id
+		}
+	}
+	for id, p := range before {
+		if _, ok := after[id]; !ok {
+			add(ForeignUnitVanished, p).Unit = id
+		}
+	}
+
+	sort.SliceStable(d.Changes, func(i, j int) bool {
+		a, b := d.Changes[i], d.Changes[j]
+		if a.Point != b.Point {
+			if a.Point.Column != b.Point.Column {
+				return a.Point.Column < b.Point.Column
+			}
+			return a.Point.Row < b.Point.Row
+		} else if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		} else if a.Direction != b.Direction {
+			return a.Direction < b.Direction
+		}
+		return a.Unit < b.Unit
+	})
+	return d
+}
+
+// foreignUnits returns the location of the foreign units seen on the map's turn.
+func foreignUnits(m *Map_t) map[ast.UnitId_t]Point_t {
+	units := map[ast.UnitId_t]Point_t{}
+	for _, t := range m.Sorted() {
+		for _, id := range t.Encounters {
+			if m.IsForeign(id) {
+				units[id] = t.Point
+			}
+		}
+	}
+	return units
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package tiles
+
+import (
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+)
+
+const (
+	// GridColumns and GridRows are the number of columns and rows in a single grid.
+	GridColumns = 30
+	GridRows    = 21
+	// Grids is the number of grids along each edge of the big map.
+	Grids = 26
+)
+
+// Point_t is the location of a tile on the big map, using absolute, 1-based
+// column and row numbers. Column 1, row 1 is the top left tile of grid AA.
+//
+// Grids have an even number of columns, so a tile has the same odd/even
+// column property on the big map as it does in its grid.
+type Point_t struct {
+	Column int
+	Row    int
+}
+
+// ToPoint converts coordinates to a point on the big map.
+// Coordinates in obscured grids don't have a location on the big map,
+// so we return false for them.
+func ToPoint(c ast.Coordinates_t) (Point_t, bool) {
+	if c.IsZero() || !c.IsValidGrid() {
+		return Point_t{}, false
+	}
+	return Point_t{
+		Column: (c.GridColumn-1)*GridColumns + c.Column,
+		Row:    (c.GridRow-1)*GridRows + c.Row,
+	}, true
+}
+
+// Coordinates converts the point back to grid coordinates.
+func (p Point_t) Coordinates() ast.Coordinates_t {
+	return ast.Coordinates_t{
+		GridRow:    (p.Row-1)/GridRows + 1,
+		GridColumn: (p.Column-1)/GridColumns + 1,
+		Column:     (p.Column-1)%GridColumns + 1,
+		Row:        (p.Row-1)%GridRows + 1,
+	}
+}
+
+// IsValid returns true if the point is on the big map.
+func (p Point_t) IsValid() bool {
+	return 1 <= p.Column && p.Column <= Grids*GridColumns && 1 <= p.Row && p.Row <= Grids*GridRows
+}
+
+// Move returns the point one tile away in the given direction.
+// Moves off the edge of the big map wrap around, the same as coordinates.
+func (p Point_t) Move(d direction.Direction_e) Point_t {
+	q, _ := ToPoint(p.Coordinates().Move(d))
+	return q
+}
+
+func (p Point_t) String() string {
+	return p.Coordinates().String()
+}
@@ -0,0 +1,165 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package tiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/playbymail/tribal/border"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/passage"
+)
+
+// Edge_e is an enum for the kinds of features on the edge between two tiles.
+type Edge_e int
+
+const (
+	NoEdge Edge_e = iota
+	BorderEdge
+	PassageEdge
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e Edge_e) MarshalJSON() ([]byte, error) {
+	return json.Marshal(EdgeToString[e])
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *Edge_e) UnmarshalJSON(data []byte) error {
+	var s string
+	var ok bool
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	} else if *e, ok = StringToEdge[s]; !ok {
+		return fmt.Errorf("invalid Edge %q", s)
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (e Edge_e) String() string {
+	if str, ok := EdgeToString[e]; ok {
+		return str
+	}
+	return fmt.Sprintf("Edge(%d)", int(e))
+}
+
+var (
+	// EdgeToString is a helper map for marshalling the enum
+	EdgeToString = map[Edge_e]string{
+		NoEdge:      "",
+		BorderEdge:  "border",
+		PassageEdge: "passage",
+	}
+	// StringToEdge is a helper map for unmarshalling the enum
+	StringToEdge = map[string]Edge_e{
+		"":        NoEdge,
+		"border":  BorderEdge,
+		"passage": PassageEdge,
+	}
+)
+
+// Mismatch_t is an edge that the tiles on either side don't agree on.
+// Borders and passages are supposed to be the same from both sides, so
+// these are usually errors in the GM's map database.
+type Mismatch_t struct {
+	Kind        Edge_e                `json:"kind"`
+	Point       Point_t               `json:"-"`
+	Coordinates ast.Coordinates_t     `json:"coordinates"`
+	Direction   direction.Direction_e `json:"direction"`
+	Neighbor    ast.Coordinates_t     `json:"neighbor"`
+	Here        string                `json:"here"`            // feature reported for the tile
+	There       string                `json:"there,omitempty"` // feature reported for the neighbor, empty if none
+}
+
+func (e *Mismatch_t) String() string {
+	opposite := direction.Opposite[e.Direction]
+	if e.There == "" {
+		return fmt.Sprintf("%s: %s %s %s, but %s has no %s %s", e.Coordinates, e.Kind, e.Here, e.Direction, e.Neighbor, e.Here, opposite)
+	}
+	return fmt.Sprintf("%s: %s %s %s, but %s has %s %s", e.Coordinates, e.Kind, e.Here, e.Direction, e.Neighbor, e.There, opposite)
+}
+
+// Reconcile mirrors the borders and passages of every tile onto the other
+// side of the edge, since a river to the north of one tile is a river to the
+// south of its neighbor. Mirroring never replaces a feature that was reported
+// for the other side, and tiles that we don't know about aren't created.
+//
+// Returns the edges that the tiles don't agree on, sorted by location. An edge
+// is suspect if the two sides report different features, or if the neighbor
+// was visited (so its report should have listed the feature) and doesn't.
+func (m *Map_t) Reconcile() []*Mismatch_t {
+	var list []*Mismatch_t
+	mismatch := func(kind Edge_e, t, n *Tile_t, d direction.Direction_e, here, there string) {
+		list = append(list, &Mismatch_t{
+			Kind:        kind,
+			Point:       t.Point,
+			Coordinates: t.Point.Coordinates(),
+			Direction:   d,
+			Neighbor:    n.Point.Coordinates(),
+			Here:        here,
+			There:       there,
+		})
+	}
+
+	// the mirrored features are applied after every edge has been checked,
+	// so that they don't hide a missing feature from the neighbor's side.
+	type mirror_t struct {
+		tile    *Tile_t
+		dir     direction.Direction_e
+		border  border.Border_e
+		passage passage.Passage_e
+	}
+	var mirrors []mirror_t
+
+	for _, t := range m.Sorted() {
+		for _, d := range direction.Directions {
+			n, ok := m.Tiles[t.Point.Move(d)]
+			if !ok {
+				continue
+			}
+			od := direction.Opposite[d]
+			if here := t.Borders[d]; here != border.None {
+				if there := n.Borders[od]; there == border.None {
+					if n.Visited {
+						mismatch(BorderEdge, t, n, d, here.String(), "")
+					}
+					mirrors = append(mirrors, mirror_t{tile: n, dir: od, border: here})
+				} else if there != here && isBefore(t.Point, n.Point) {
+					// both sides report a feature, so only report it once
+					mismatch(BorderEdge, t, n, d, here.String(), there.String())
+				}
+			}
+			if here := t.Passages[d]; here != passage.None {
+				if there := n.Passages[od]; there == passage.None {
+					if n.Visited {
+						mismatch(PassageEdge, t, n, d, here.String(), "")
+					}
+					mirrors = append(mirrors, mirror_t{tile: n, dir: od, passage: here})
+				} else if there != here && isBefore(t.Point, n.Point) {
+					mismatch(PassageEdge, t, n, d, here.String(), there.String())
+				}
+			}
+		}
+	}
+
+	for _, e := range mirrors {
+		if e.border != border.None {
+			e.tile.Borders[e.dir] = e.border
+		}
+		if e.passage != passage.None {
+			e.tile.Passages[e.dir] = e.passage
+		}
+	}
+
+	return list
+}
+
+// isBefore returns true if the first point sorts before the second.
+func isBefore(a, b Point_t) bool {
+	if a.Column != b.Column {
+		return a.Column < b.Column
+	}
+	return a.Row < b.Row
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package tiles
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+)
+
+// Source_e is an enum for where a tile fact came from.
+//
+// When facts about the terrain disagree, we use these rules to pick the one
+// that goes on the map:
+//
+//  1. The fact from the source with the higher confidence wins.
+//  2. If the confidence is the same, the newer fact wins, except that a terrain
+//     group (like "unknown water") never replaces a specific terrain.
+//  3. An override pins the terrain. Only a newer override replaces it.
+type Source_e int
+
+const (
+	NoSource       Source_e = iota
+	NeighborSource          // seen from an adjacent tile
+	FleetSource             // seen by a fleet passing through or by
+	AllianceSource          // imported from another clan's report
+	VisitSource             // seen by a unit moving into or through the tile
+	StatusSource            // the status line of a unit in the tile
+	OverrideSource          // set by the player
+)
+
+// Confidence returns how much we trust facts from the source.
+func (e Source_e) Confidence() Confidence_e {
+	switch e {
+	case NeighborSource:
+		return NeighborConfidence
+	case FleetSource:
+		return FleetConfidence
+	case AllianceSource:
+		return AllianceConfidence
+	case VisitSource, StatusSource:
+		return VisitConfidence
+	case OverrideSource:
+		return OverrideConfidence
+	}
+	return NoConfidence
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e Source_e) MarshalJSON() ([]byte, error) {
+	return json.Marshal(SourceToString[e])
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *Source_e) UnmarshalJSON(data []byte) error {
+	var s string
+	var ok bool
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	} else if *e, ok = StringToSource[s]; !ok {
+		return fmt.Errorf("invalid Source %q", s)
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (e Source_e) String() string {
+	if str, ok := SourceToString[e]; ok {
+		return str
+	}
+	return fmt.Sprintf("Source(%d)", int(e))
+}
+
+var (
+	// SourceToString is a helper map for marshalling the enum
+	SourceToString = map[Source_e]string{
+		NoSource:       "",
+		NeighborSource: "neighbor",
+		FleetSource:    "fleet",
+		AllianceSource: "alliance",
+		VisitSource:    "visit",
+		StatusSource:   "status",
+		OverrideSource: "override",
+	}
+	// StringToSource is a helper map for unmarshalling the enum
+	StringToSource = map[string]Source_e{
+		"":         NoSource,
+		"neighbor": NeighborSource,
+		"fleet":    FleetSource,
+		"alliance": AllianceSource,
+		"visit":    VisitSource,
+		"status":   StatusSource,
+		"override": OverrideSource,
+	}
+)
+
+// Fact_e is an enum for the kinds of facts that we record for a tile.
+type Fact_e int
+
+const (
+	NoFact Fact_e = iota
+	TerrainFact
+	SettlementFact
+	BorderFact
+	PassageFact
+)
+
+// MarshalJSON implements the json.Marshaler interface.
+func (e Fact_e) MarshalJSON() ([]byte, error) {
+	return json.Marshal(FactToString[e])
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface.
+func (e *Fact_e) UnmarshalJSON(data []byte) error {
+	var s string
+	var ok bool
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	} else if *e, ok = StringToFact[s]; !ok {
+		return fmt.Errorf("invalid Fact %q", s)
+	}
+	return nil
+}
+
+// String implements the fmt.Stringer interface.
+func (e Fact_e) String() string {
+	if str, ok := FactToString[e]; ok {
+		return str
+	}
+	return fmt.Sprintf("Fact(%d)", int(e))
+}
+
+var (
+	// FactToString is a helper map for marshalling the enum
+	FactToString = map[Fact_e]string{
+		NoFact:         "",
+		TerrainFact:    "terrain",
+		SettlementFact: "settlement",
+		BorderFact:     "border",
+		PassageFact:    "passage",
+	}
+	// StringToFact is a helper map for unmarshalling the enum
+	StringToFact = map[string]Fact_e{
+		"":           NoFact,
+		"terrain":    TerrainFact,
+		"settlement": SettlementFact,
+		"border":     BorderFact,
+		"passage":    PassageFact,
+	}
+)
+
+// Fact_t is a single observation of a tile and where it came from.
+type Fact_t struct {
+	Kind      Fact_e                `json:"kind"`
+	Direction direction.Direction_e `json:"direction,omitempty"` // edge, for borders and passages
+	Value     string                `json:"value"`
+	Source    Source_e              `json:"source"`
+	Turn      ast.TurnId_t          `json:"turn"`
+	Unit      ast.UnitId_t          `json:"unit,omitempty"` // unit that made the observation
+	Line      int                   `json:"line,omitempty"` // line in the normalized report, 0 if unknown; see parser.Report_t.Source
+}
+
+func (f *Fact_t) String() string {
+	if f.Direction != direction.None {
+		return fmt.Sprintf("%s %s %s (%s %s)", f.Kind, f.Value, f.Direction, f.Source, f.Unit)
+	}
+	return fmt.Sprintf("%s %s (%s %s)", f.Kind, f.Value, f.Source, f.Unit)
+}
+
+// origin_t is where a set of observations came from.
+type origin_t struct {
+	source Source_e
+	turn   ast.TurnId_t
+	unit   ast.UnitId_t
+	line   int
+}
+
+// fact records an observation of the tile.
+// A step that fails reports the same tile again, so duplicates are dropped.
+func (t *Tile_t) fact(o origin_t, kind Fact_e, d direction.Direction_e, value string) {
+	f := &Fact_t{
+		Kind:      kind,
+		Direction: d,
+		Value:     value,
+		Source:    o.source,
+		Turn:      o.turn,
+		Unit:      o.unit,
+		Line:      o.line,
+	}
+	for _, have := range t.Facts {
+		if *have == *f {
+			return
+		}
+	}
+	t.Facts = append(t.Facts, f)
+}
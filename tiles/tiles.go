@@ -0,0 +1,375 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+// Package tiles implements the tile model that the renderers draw from.
+//
+// The model is built by folding the units from one or more turn reports,
+// in turn order, into a map of tiles. Each tile holds what we know about
+// a single hex: the terrain, the edges, and the things that we found there.
+package tiles
+
+import (
+	"github.com/playbymail/tribal/border"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/passage"
+	"github.com/playbymail/tribal/resource"
+	"github.com/playbymail/tribal/terrain"
+	"sort"
+)
+
+// Tile_t is what we know about a single hex on the map.
+type Tile_t struct {
+	Point     Point_t
+	Terrain   terrain.Terrain_e
+	Source    Source_e     // source of the terrain
+	FirstSeen ast.TurnId_t // turn the tile was first observed
+	LastSeen  ast.TurnId_t // turn the tile was last observed
+	Visited   bool         // true if a unit entered the tile
+	HexName   *ast.HexName_t
+	Resources []resource.Resource_e
+	// Neighbors is the terrain seen in adjacent tiles from this tile.
+	Neighbors map[direction.Direction_e]terrain.Terrain_e
+	Borders   map[direction.Direction_e]border.Border_e
+	Passages  map[direction.Direction_e]passage.Passage_e
+	// Units are our units in the tile at the end of the most recent turn.
+	Units []ast.UnitId_t
+	// Encounters are the units seen in the tile during the most recent turn.
+	Encounters []ast.UnitId_t
+	// Facts are the observations of the tile, in the order they were made.
+	Facts []*Fact_t
+}
+
+// Map_t is the collection of tiles that we know about.
+type Map_t struct {
+	Clan  string // clan that owns the units, e.g. "987"
+	Turn  ast.TurnId_t
+	Tiles map[Point_t]*Tile_t
+	units map[ast.UnitId_t]Point_t // location of our units
+}
+
+// New returns an empty map.
+func New() *Map_t {
+	return &Map_t{
+		Tiles: map[Point_t]*Tile_t{},
+		units: map[ast.UnitId_t]Point_t{},
+	}
+}
+
+// Build returns a map built from all the units, applied in turn order.
+func Build(units []*ast.Unit_t) *Map_t {
+	return BuildThrough(units, -1)
+}
+
+// BuildThrough returns a map built from the units with turns up to and
+// including the given turn. A negative turn includes all units.
+func BuildThrough(units []*ast.Unit_t, through ast.TurnId_t) *Map_t {
+	list := make([]*ast.Unit_t, 0, len(units))
+	for _, u := range units {
+		if u == nil {
+			continue
+		} else if through >= 0 && turnOf(u) > through {
+			continue
+		}
+		list = append(list, u)
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return turnOf(list[i]) < turnOf(list[j])
+	})
+	m := New()
+	for _, u := range list {
+		m.Add(u)
+	}
+	return m
+}
+
+// Turns returns the distinct turns in the units, in order.
+func Turns(units []*ast.Unit_t) []ast.TurnId_t {
+	seen := map[ast.TurnId_t]bool{}
+	var list []ast.TurnId_t
+	for _, u := range units {
+		if u == nil {
+			continue
+		} else if turn := turnOf(u); !seen[turn] {
+			list, seen[turn] = append(list, turn), true
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i] < list[j]
+	})
+	return list
+}
+
+// Add folds the unit's movement and status into the map.
+// Units must be added in turn order.
+func (m *Map_t) Add(u *ast.Unit_t) {
+	turn := turnOf(u)
+	if m.Clan == "" && len(u.Id) >= 4 {
+		m.Clan = string(u.Id[1:4])
+	}
+	if turn > m.Turn {
+		// encounters are only good for the turn they were seen in
+		for _, t := range m.Tiles {
+			t.Encounters = nil
+		}
+		m.Turn = turn
+	}
+
+	// reports from other clans are imported from our allies.
+	// our fleets only see the tiles that they pass through or by.
+	visit, status := VisitSource, StatusSource
+	if m.IsForeign(u.Id) {
+		visit, status = AllianceSource, AllianceSource
+	} else if isFleet(u.Id) {
+		visit = FleetSource
+	}
+
+	if u.Moves != nil {
+		for _, step := range u.Moves.Marches {
+			t := m.observe(step.To, turn)
+			if t == nil {
+				continue
+			}
+			if step.Direction != direction.None {
+				t.Visited = true
+			}
+			o := origin_t{source: visit, turn: turn, unit: u.Id, line: step.Line}
+			t.update(o, step.Terrain, step.HexName, nil, step.Neighbors, step.Borders, step.Passages)
+			m.project(t, o, step.Neighbors)
+			if step.Failure == ast.NoFord {
+				m.noFord(t, o, step.Borders)
+			}
+		}
+		for _, step := range u.Moves.Patrols {
+			t := m.observe(step.To, turn)
+			if t == nil {
+				continue
+			}
+			if step.Direction != direction.None {
+				t.Visited = true
+			}
+			o := origin_t{source: visit, turn: turn, unit: u.Id, line: step.Line}
+			t.update(o, step.Terrain, step.HexName, step.Resources, step.Neighbors, step.Borders, step.Passages)
+			t.Encounters = addUnits(t.Encounters, step.Encounters...)
+			m.project(t, o, step.Neighbors)
+			if step.Failure == ast.NoFord {
+				m.noFord(t, o, step.Borders)
+			}
+		}
+	}
+	if s := u.Status; s != nil {
+		if t := m.observe(s.Tile.Coordinates, turn); t != nil {
+			t.Visited = true
+			o := origin_t{source: status, turn: turn, unit: u.Id, line: s.Line}
+			t.update(o, s.Tile.Terrain, s.Tile.HexName, s.Tile.Resources, s.Tile.Neighbors, s.Tile.Borders, s.Tile.Passages)
+			t.Encounters = addUnits(t.Encounters, s.Tile.Encounters...)
+			m.project(t, o, s.Tile.Neighbors)
+			// the status line always names the settlement, so if it doesn't, the settlement is gone
+			if s.Tile.HexName == nil {
+				t.HexName = nil
+			}
+		}
+	}
+
+	// move the unit marker to the unit's current location
+	if p, ok := m.units[u.Id]; ok {
+		if t, ok := m.Tiles[p]; ok {
+			t.Units = removeUnit(t.Units, u.Id)
+		}
+		delete(m.units, u.Id)
+	}
+	if t := m.observe(u.CurrentHex, turn); t != nil {
+		t.Units = addUnits(t.Units, u.Id)
+		m.units[u.Id] = t.Point
+	}
+}
+
+// Bounds returns the top left and bottom right points of the known tiles.
+// Returns false if the map is empty.
+func (m *Map_t) Bounds() (min, max Point_t, ok bool) {
+	for p := range m.Tiles {
+		if !ok {
+			min, max, ok = p, p, true
+			continue
+		}
+		if p.Column < min.Column {
+			min.Column = p.Column
+		}
+		if p.Row < min.Row {
+			min.Row = p.Row
+		}
+		if p.Column > max.Column {
+			max.Column = p.Column
+		}
+		if p.Row > max.Row {
+			max.Row = p.Row
+		}
+	}
+	return min, max, ok
+}
+
+// IsForeign returns true if the unit doesn't belong to the map's clan.
+func (m *Map_t) IsForeign(id ast.UnitId_t) bool {
+	return len(id) < 4 || string(id[1:4]) != m.Clan
+}
+
+// Sorted returns the tiles sorted by column, then row.
+func (m *Map_t) Sorted() []*Tile_t {
+	list := make([]*Tile_t, 0, len(m.Tiles))
+	for _, t := range m.Tiles {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Point.Column != list[j].Point.Column {
+			return list[i].Point.Column < list[j].Point.Column
+		}
+		return list[i].Point.Row < list[j].Point.Row
+	})
+	return list
+}
+
+// observe returns the tile at the coordinates, creating it if needed.
+// Returns nil if the coordinates can't be placed on the big map.
+func (m *Map_t) observe(c ast.Coordinates_t, turn ast.TurnId_t) *Tile_t {
+	p, ok := ToPoint(c)
+	if !ok {
+		return nil
+	}
+	t, ok := m.Tiles[p]
+	if !ok {
+		t = &Tile_t{
+			Point:     p,
+			FirstSeen: turn,
+			Neighbors: map[direction.Direction_e]terrain.Terrain_e{},
+			Borders:   map[direction.Direction_e]border.Border_e{},
+			Passages:  map[direction.Direction_e]passage.Passage_e{},
+		}
+		m.Tiles[p] = t
+	}
+	t.LastSeen = turn
+	return t
+}
+
+// project records the terrain seen in the neighbors of a tile on the
+// adjacent tiles. Nobody entered those tiles, so the terrain is recorded
+// with less confidence than a visit. This is how the map learns about
+// coastlines and mountain ranges that we've only seen from next door.
+func (m *Map_t) project(from *Tile_t, o origin_t, neighbors []*ast.Neighbor_t) {
+	o.source = NeighborSource
+	for _, n := range neighbors {
+		for _, d := range n.Direction {
+			if t := m.observe(from.Point.Coordinates().Move(d), o.turn); t != nil {
+				t.sight(o, n.Terrain)
+			}
+		}
+	}
+}
+
+// Override sets the terrain of the tile at the point, creating the tile if
+// needed. Overrides come from the player and win over every observation.
+func (m *Map_t) Override(p Point_t, ter terrain.Terrain_e) {
+	if t := m.observe(p.Coordinates(), m.Turn); t != nil {
+		t.sight(origin_t{source: OverrideSource, turn: m.Turn}, ter)
+	}
+}
+
+// noFord records the border from a failed river or canal crossing on the
+// other side of the edge, too. Nobody has seen the neighbor yet, so it is
+// added to the map without terrain.
+func (m *Map_t) noFord(from *Tile_t, o origin_t, borders []*ast.Border_t) {
+	for _, b := range borders {
+		for _, d := range b.Direction {
+			if t := m.observe(from.Point.Coordinates().Move(d), o.turn); t != nil {
+				t.Borders[direction.Opposite[d]] = b.Border
+				t.fact(o, BorderFact, direction.Opposite[d], b.Border.String())
+			}
+		}
+	}
+}
+
+// sight records an observation of the terrain and puts it on the map
+// if it wins over what we have. See Source_e for the rules.
+func (t *Tile_t) sight(o origin_t, ter terrain.Terrain_e) {
+	if ter == terrain.Blank {
+		return
+	}
+	t.fact(o, TerrainFact, direction.None, terrain.EnumToString[ter])
+	if t.Source == OverrideSource && o.source != OverrideSource {
+		return
+	} else if o.source.Confidence() < t.Source.Confidence() {
+		return
+	} else if o.source.Confidence() == t.Source.Confidence() && ter.IsUnknown() && t.Terrain != terrain.Blank && !t.Terrain.IsUnknown() {
+		return
+	}
+	t.Terrain, t.Source = ter, o.source
+}
+
+// update records the observations for the tile.
+// The terrain follows the rules in Source_e. Newer observations of everything
+// else replace older ones, but we never forget what we knew.
+func (t *Tile_t) update(o origin_t, ter terrain.Terrain_e, name *ast.HexName_t, resources []resource.Resource_e, neighbors []*ast.Neighbor_t, borders []*ast.Border_t, passages []*ast.Passage_t) {
+	t.sight(o, ter)
+	if name != nil {
+		t.HexName = name
+		t.fact(o, SettlementFact, direction.None, name.Name)
+	}
+	for _, r := range resources {
+		found := false
+		for _, have := range t.Resources {
+			found = found || have == r
+		}
+		if !found {
+			t.Resources = append(t.Resources, r)
+		}
+	}
+	for _, n := range neighbors {
+		for _, d := range n.Direction {
+			t.Neighbors[d] = n.Terrain
+		}
+	}
+	for _, b := range borders {
+		for _, d := range b.Direction {
+			t.Borders[d] = b.Border
+			t.fact(o, BorderFact, d, b.Border.String())
+		}
+	}
+	for _, p := range passages {
+		for _, d := range p.Direction {
+			t.Passages[d] = p.Passage
+			t.fact(o, PassageFact, d, p.Passage.String())
+		}
+	}
+}
+
+// isFleet returns true if the unit is a fleet (e.g. 0987f1).
+func isFleet(id ast.UnitId_t) bool {
+	return len(id) == 6 && (id[4] == 'f' || id[4] == 'F')
+}
+
+func addUnits(list []ast.UnitId_t, ids ...ast.UnitId_t) []ast.UnitId_t {
+	for _, id := range ids {
+		found := false
+		for _, have := range list {
+			found = found || have == id
+		}
+		if !found {
+			list = append(list, id)
+		}
+	}
+	return list
+}
+
+func removeUnit(list []ast.UnitId_t, id ast.UnitId_t) []ast.UnitId_t {
+	for n, have := range list {
+		if have == id {
+			return append(list[:n], list[n+1:]...)
+		}
+	}
+	return list
+}
+
+func turnOf(u *ast.Unit_t) ast.TurnId_t {
+	if u.Turn == nil {
+		return 0
+	}
+	return u.Turn.Id
+}
@@ -0,0 +1,310 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package tiles_test
+
+import (
+	"github.com/playbymail/tribal/border"
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+	"github.com/playbymail/tribal/passage"
+	"github.com/playbymail/tribal/terrain"
+	"github.com/playbymail/tribal/tiles"
+	"testing"
+)
+
+func TestPoint(t *testing.T) {
+	for _, tc := range []struct {
+		text string
+		want tiles.Point_t
+	}{
+		{"aa 0101", tiles.Point_t{Column: 1, Row: 1}},
+		{"kp 0608", tiles.Point_t{Column: 15*30 + 6, Row: 10*21 + 8}},
+		{"zz 3021", tiles.Point_t{Column: 26 * 30, Row: 26 * 21}},
+	} {
+		c, err := ast.TextToCoordinates([]byte(tc.text))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.text, err)
+		}
+		got, ok := tiles.ToPoint(c)
+		if !ok {
+			t.Fatalf("%s: ToPoint: want ok, got !ok", tc.text)
+		} else if got != tc.want {
+			t.Errorf("%s: ToPoint: want %+v, got %+v", tc.text, tc.want, got)
+		} else if got.Coordinates() != c {
+			t.Errorf("%s: Coordinates: want %s, got %s", tc.text, c, got.Coordinates())
+		}
+	}
+
+	// moving across a grid boundary and back should return to the same tile
+	p := tiles.Point_t{Column: 30, Row: 21}
+	for _, d := range []direction.Direction_e{direction.North, direction.NorthEast, direction.SouthEast, direction.South, direction.SouthWest, direction.NorthWest} {
+		if got := p.Move(d).Move(direction.Opposite[d]); got != p {
+			t.Errorf("%s: move %s and back: want %+v, got %+v", p, d, p, got)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	kp0608 := ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8}
+	status := func(turn ast.TurnId_t, ter terrain.Terrain_e, name *ast.HexName_t, encounters ...ast.UnitId_t) *ast.Unit_t {
+		return &ast.Unit_t{
+			Id:         "0987",
+			CurrentHex: kp0608,
+			Turn:       &ast.Turn_t{Id: turn},
+			Status: &ast.Status_t{
+				Unit: "0987",
+				Tile: ast.Tile_t{Coordinates: kp0608, Terrain: ter, HexName: name, Encounters: encounters},
+			},
+		}
+	}
+	village := &ast.HexName_t{Name: "Los Angeles"}
+	units := []*ast.Unit_t{
+		status(5, terrain.UnknownLand, village, "0987", "0123"),
+		status(6, terrain.Prairie, nil, "0987", "0456"),
+	}
+
+	var got []string
+	for _, c := range tiles.DiffTurns(units, 5, 6).Changes {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"KP 0608: terrain refined: UL -> PR",
+		"KP 0608: vanished settlement: Los Angeles",
+		"KP 0608: foreign unit appeared: 0456",
+		"KP 0608: foreign unit vanished: 0123",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("changes: want %d, got %d: %q", len(want), len(got), got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("change %d: want %q, got %q", i, want[i], got[i])
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	kp0608 := ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8}
+	unit := &ast.Unit_t{
+		Id:         "0987",
+		CurrentHex: kp0608,
+		Turn:       &ast.Turn_t{Id: 5},
+		Moves: &ast.Moves_t{Marches: []*ast.March_t{
+			{
+				From:      kp0608,
+				To:        kp0608,
+				Failure:   ast.NotEnoughMPs,
+				Neighbors: []*ast.Neighbor_t{{Terrain: terrain.GrassyHills, Direction: []direction.Direction_e{direction.SouthWest}}},
+			},
+		}},
+		Status: &ast.Status_t{
+			Unit: "0987",
+			Tile: ast.Tile_t{
+				Coordinates: kp0608,
+				Terrain:     terrain.Prairie,
+				Neighbors:   []*ast.Neighbor_t{{Terrain: terrain.Ocean, Direction: []direction.Direction_e{direction.North}}},
+			},
+		},
+	}
+	m := tiles.Build([]*ast.Unit_t{unit})
+
+	from, _ := tiles.ToPoint(kp0608)
+	if got, ok := m.Tiles[from.Move(direction.SouthWest)]; !ok {
+		t.Errorf("blocked neighbor: want tile, got none")
+	} else if got.Terrain != terrain.GrassyHills {
+		t.Errorf("blocked neighbor: want %s, got %s", terrain.GrassyHills, got.Terrain)
+	} else if got.Visited {
+		t.Errorf("blocked neighbor: want not visited, got visited")
+	}
+	if got, ok := m.Tiles[from.Move(direction.North)]; !ok {
+		t.Errorf("status neighbor: want tile, got none")
+	} else if got.Terrain != terrain.Ocean || got.Source != tiles.NeighborSource {
+		t.Errorf("status neighbor: want %s/%s, got %s/%s", terrain.Ocean, tiles.NeighborSource, got.Terrain, got.Source)
+	}
+
+	// a neighbor sighting never replaces the terrain from a visit
+	visit := &ast.Unit_t{
+		Id:         "0987",
+		CurrentHex: from.Move(direction.SouthWest).Coordinates(),
+		Turn:       &ast.Turn_t{Id: 6},
+		Status: &ast.Status_t{
+			Unit: "0987",
+			Tile: ast.Tile_t{
+				Coordinates: from.Move(direction.SouthWest).Coordinates(),
+				Terrain:     terrain.Prairie,
+				Neighbors:   []*ast.Neighbor_t{{Terrain: terrain.Lake, Direction: []direction.Direction_e{direction.NorthEast}}},
+			},
+		},
+	}
+	m = tiles.Build([]*ast.Unit_t{unit, visit})
+	if got := m.Tiles[from]; got.Terrain != terrain.Prairie || got.Source != tiles.StatusSource {
+		t.Errorf("visited tile: want %s/%s, got %s/%s", terrain.Prairie, tiles.StatusSource, got.Terrain, got.Source)
+	}
+
+	// a missing ford is recorded on both sides of the edge, even if nobody has seen the neighbor
+	ford := &ast.Unit_t{
+		Id:         "0987",
+		CurrentHex: kp0608,
+		Turn:       &ast.Turn_t{Id: 5},
+		Moves: &ast.Moves_t{Patrols: []*ast.Patrol_t{
+			{
+				Patrol:  1,
+				From:    kp0608,
+				To:      kp0608,
+				Terrain: terrain.Prairie,
+				Failure: ast.NoFord,
+				Borders: []*ast.Border_t{{Border: border.River, Direction: []direction.Direction_e{direction.SouthEast}}},
+			},
+		}},
+	}
+	m = tiles.Build([]*ast.Unit_t{ford})
+	if got := m.Tiles[from].Borders[direction.SouthEast]; got != border.River {
+		t.Errorf("no ford: want River SE, got %q", got)
+	}
+	if got, ok := m.Tiles[from.Move(direction.SouthEast)]; !ok {
+		t.Errorf("no ford: want neighbor, got none")
+	} else if got.Borders[direction.NorthWest] != border.River {
+		t.Errorf("no ford: want neighbor River NW, got %q", got.Borders[direction.NorthWest])
+	} else if got.Visited || got.Terrain != terrain.Blank {
+		t.Errorf("no ford: want neighbor not visited and blank, got %v/%s", got.Visited, got.Terrain)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	kp0608 := ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8}
+	from, _ := tiles.ToPoint(kp0608)
+	north, south := from.Move(direction.North), from.Move(direction.South)
+	// the unit reports a river north and a ford south, then moves north
+	// to a tile that doesn't report the river. the tile to the south is
+	// only seen from next door, so it can't be a mismatch.
+	unit := &ast.Unit_t{
+		Id:         "0987",
+		CurrentHex: north.Coordinates(),
+		Turn:       &ast.Turn_t{Id: 5},
+		Moves: &ast.Moves_t{Marches: []*ast.March_t{
+			{
+				From:      kp0608,
+				To:        kp0608,
+				Terrain:   terrain.Prairie,
+				Neighbors: []*ast.Neighbor_t{{Terrain: terrain.Prairie, Direction: []direction.Direction_e{direction.South}}},
+				Borders:   []*ast.Border_t{{Border: border.River, Direction: []direction.Direction_e{direction.North}}},
+				Passages:  []*ast.Passage_t{{Passage: passage.Ford, Direction: []direction.Direction_e{direction.South}}},
+			},
+			{
+				From:      kp0608,
+				Direction: direction.North,
+				To:        north.Coordinates(),
+				Terrain:   terrain.GrassyHills,
+			},
+		}},
+	}
+	m := tiles.Build([]*ast.Unit_t{unit})
+	list := m.Reconcile()
+	if len(list) != 1 {
+		t.Fatalf("mismatches: want 1, got %d: %v", len(list), list)
+	} else if got := list[0]; got.Kind != tiles.BorderEdge || got.Point != from || got.Direction != direction.North || got.There != "" {
+		t.Errorf("mismatch: want border River N with no River S, got %s", got)
+	}
+	if got := m.Tiles[north].Borders[direction.South]; got != border.River {
+		t.Errorf("north: want River S, got %q", got)
+	}
+	if got := m.Tiles[south].Passages[direction.North]; got != passage.Ford {
+		t.Errorf("south: want Ford N, got %q", got)
+	}
+}
+
+func TestConflicts(t *testing.T) {
+	kp0608 := ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8}
+	from, _ := tiles.ToPoint(kp0608)
+	south := from.Move(direction.South)
+	status := func(id ast.UnitId_t, at ast.Coordinates_t, ter terrain.Terrain_e, line int, neighbors ...*ast.Neighbor_t) *ast.Unit_t {
+		return &ast.Unit_t{
+			Id:         id,
+			CurrentHex: at,
+			Turn:       &ast.Turn_t{Id: 5},
+			Status: &ast.Status_t{
+				Unit: id,
+				Tile: ast.Tile_t{Coordinates: at, Terrain: ter, Neighbors: neighbors},
+				Line: line,
+			},
+		}
+	}
+	// the tribe says it is on prairie, but the courier to the south sees ocean.
+	// the element sees "unknown land," which the prairie refines.
+	units := []*ast.Unit_t{
+		status("0987", kp0608, terrain.Prairie, 4),
+		status("0987c1", south.Coordinates(), terrain.GrassyHills, 9,
+			&ast.Neighbor_t{Terrain: terrain.Ocean, Direction: []direction.Direction_e{direction.North}}),
+		status("0987e1", kp0608, terrain.UnknownLand, 14),
+	}
+	m := tiles.Build(units)
+	list := m.Conflicts()
+	if len(list) != 1 {
+		t.Fatalf("conflicts: want 1, got %d: %v", len(list), list)
+	} else if got := list[0]; got.Point != from || got.Kind != tiles.TerrainFact || len(got.Facts) != 3 {
+		t.Errorf("conflict: want %s terrain with 3 facts, got %s with %d facts", from, got, len(got.Facts))
+	} else if f := got.Facts[1]; f.Source != tiles.NeighborSource || f.Unit != "0987c1" || f.Line != 9 {
+		t.Errorf("conflict: fact: want neighbor 0987c1 line 9, got %s line %d", f, f.Line)
+	}
+	if got := m.Tiles[from]; got.Terrain != terrain.Prairie || got.Source != tiles.StatusSource {
+		t.Errorf("terrain: want %s/%s, got %s/%s", terrain.Prairie, tiles.StatusSource, got.Terrain, got.Source)
+	}
+
+	// an ally's report never replaces what our own units saw
+	m.Add(status("0123", kp0608, terrain.Swamp, 4))
+	if got := m.Tiles[from]; got.Terrain != terrain.Prairie || got.Source != tiles.StatusSource {
+		t.Errorf("alliance: want %s/%s, got %s/%s", terrain.Prairie, tiles.StatusSource, got.Terrain, got.Source)
+	}
+
+	// "unknown water" never replaces the ocean that we already know about
+	m = tiles.Build([]*ast.Unit_t{
+		status("0987c1", south.Coordinates(), terrain.GrassyHills, 9,
+			&ast.Neighbor_t{Terrain: terrain.Ocean, Direction: []direction.Direction_e{direction.North}}),
+		status("0987c1", south.Coordinates(), terrain.GrassyHills, 9,
+			&ast.Neighbor_t{Terrain: terrain.UnknownWater, Direction: []direction.Direction_e{direction.North}}),
+	})
+	if got := m.Tiles[from]; got.Terrain != terrain.Ocean || got.Source != tiles.NeighborSource {
+		t.Errorf("unknown water: want %s/%s, got %s/%s", terrain.Ocean, tiles.NeighborSource, got.Terrain, got.Source)
+	}
+}
+
+func TestFleetAndOverride(t *testing.T) {
+	kp0608 := ast.Coordinates_t{GridRow: 11, GridColumn: 16, Column: 6, Row: 8}
+	at, _ := tiles.ToPoint(kp0608)
+	march := func(id ast.UnitId_t, ter terrain.Terrain_e, line int) *ast.Unit_t {
+		return &ast.Unit_t{
+			Id:         id,
+			CurrentHex: kp0608,
+			Turn:       &ast.Turn_t{Id: 5},
+			Moves: &ast.Moves_t{
+				Marches: []*ast.March_t{{Direction: direction.North, To: kp0608, Terrain: ter, Line: line}},
+			},
+		}
+	}
+
+	// a fleet sailing by sees "unknown water," and the tribe that lands there sees ocean
+	m := tiles.Build([]*ast.Unit_t{march("0987f1", terrain.UnknownWater, 3), march("0987", terrain.Ocean, 7)})
+	if got := m.Tiles[at]; got.Terrain != terrain.Ocean || got.Source != tiles.VisitSource {
+		t.Errorf("fleet then visit: want %s/%s, got %s/%s", terrain.Ocean, tiles.VisitSource, got.Terrain, got.Source)
+	} else if f := got.Facts[0]; f.Source != tiles.FleetSource || f.Unit != "0987f1" {
+		t.Errorf("fleet then visit: fact: want fleet 0987f1, got %s", f)
+	}
+
+	// a fleet never replaces what a visit saw
+	m = tiles.Build([]*ast.Unit_t{march("0987", terrain.Ocean, 7), march("0987f1", terrain.Lake, 3)})
+	if got := m.Tiles[at]; got.Terrain != terrain.Ocean || got.Source != tiles.VisitSource {
+		t.Errorf("visit then fleet: want %s/%s, got %s/%s", terrain.Ocean, tiles.VisitSource, got.Terrain, got.Source)
+	}
+
+	// an override wins over every observation, even newer ones
+	m.Override(at, terrain.Swamp)
+	m.Add(march("0987", terrain.Prairie, 7))
+	if got := m.Tiles[at]; got.Terrain != terrain.Swamp || got.Source != tiles.OverrideSource {
+		t.Errorf("override: want %s/%s, got %s/%s", terrain.Swamp, tiles.OverrideSource, got.Terrain, got.Source)
+	}
+	// only a newer override replaces it
+	m.Override(at, terrain.Prairie)
+	if got := m.Tiles[at]; got.Terrain != terrain.Prairie || got.Source != tiles.OverrideSource {
+		t.Errorf("override: want %s/%s, got %s/%s", terrain.Prairie, tiles.OverrideSource, got.Terrain, got.Source)
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 2025 Michael D Henderson. All rights reserved.
+
+package tiles
+
+import (
+	"github.com/playbymail/tribal/direction"
+	"github.com/playbymail/tribal/parser/ast"
+)
+
+// Track_t is a single step that a unit took from one tile to a neighbor.
+type Track_t struct {
+	Unit ast.UnitId_t
+	Turn ast.TurnId_t
+	From Point_t
+	To   Point_t
+}
+
+// Tracks returns the steps that units took during the turn, in report order.
+// Steps that didn't leave the tile, or that can't be placed on the big map,
+// are skipped.
+func Tracks(units []*ast.Unit_t, turn ast.TurnId_t) []Track_t {
+	var list []Track_t
+	add := func(id ast.UnitId_t, from ast.Coordinates_t, d direction.Direction_e, to ast.Coordinates_t) {
+		if d == direction.None {
+			return
+		}
+		fp, ok := ToPoint(from)
+		if !ok {
+			return
+		}
+		tp, ok := ToPoint(to)
+		if !ok || fp == tp {
+			return
+		}
+		list = append(list, Track_t{Unit: id, Turn: turn, From: fp, To: tp})
+	}
+	for _, u := range units {
+		if u == nil || turnOf(u) != turn || u.Moves == nil {
+			continue
+		}
+		for _, step := range u.Moves.Marches {
+			add(u.Id, step.From, step.Direction, step.To)
+		}
+		for _, step := range u.Moves.Patrols {
+			add(step.Id, step.From, step.Direction, step.To)
+		}
+	}
+	return list
+}